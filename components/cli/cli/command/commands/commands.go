@@ -19,6 +19,7 @@ import (
 	"github.com/docker/cli/cli/command/secret"
 	"github.com/docker/cli/cli/command/service"
 	"github.com/docker/cli/cli/command/stack"
+	"github.com/docker/cli/cli/command/swap"
 	"github.com/docker/cli/cli/command/swarm"
 	"github.com/docker/cli/cli/command/system"
 	"github.com/docker/cli/cli/command/trust"
@@ -83,6 +84,9 @@ func AddCommands(cmd *cobra.Command, dockerCli command.Cli) {
 		// trust
 		trust.NewTrustCommand(dockerCli),
 
+		// swap
+		swap.NewSwapCommand(dockerCli),
+
 		// volume
 		volume.NewVolumeCommand(dockerCli),
 
@@ -29,6 +29,8 @@ type fakeClient struct {
 	containerListFunc       func(types.ContainerListOptions) ([]types.Container, error)
 	containerExportFunc     func(string) (io.ReadCloser, error)
 	containerExecResizeFunc func(id string, options types.ResizeOptions) error
+	containerHibernateFunc  func(container string) error
+	containerResumeFunc     func(container string) error
 	Version                 string
 }
 
@@ -143,3 +145,17 @@ func (f *fakeClient) ContainerExecResize(_ context.Context, id string, options t
 	}
 	return nil
 }
+
+func (f *fakeClient) ContainerHibernate(_ context.Context, container string) error {
+	if f.containerHibernateFunc != nil {
+		return f.containerHibernateFunc(container)
+	}
+	return nil
+}
+
+func (f *fakeClient) ContainerResume(_ context.Context, container string) error {
+	if f.containerResumeFunc != nil {
+		return f.containerResumeFunc(container)
+	}
+	return nil
+}
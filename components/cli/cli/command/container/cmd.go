@@ -22,12 +22,15 @@ func NewContainerCommand(dockerCli command.Cli) *cobra.Command {
 		NewDiffCommand(dockerCli),
 		NewExecCommand(dockerCli),
 		NewExportCommand(dockerCli),
+		NewHibernateCommand(dockerCli),
 		NewKillCommand(dockerCli),
 		NewLogsCommand(dockerCli),
+		NewOOMReportCommand(dockerCli),
 		NewPauseCommand(dockerCli),
 		NewPortCommand(dockerCli),
 		NewRenameCommand(dockerCli),
 		NewRestartCommand(dockerCli),
+		NewResumeCommand(dockerCli),
 		NewRmCommand(dockerCli),
 		NewRunCommand(dockerCli),
 		NewStartCommand(dockerCli),
@@ -0,0 +1,51 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type hibernateOptions struct {
+	containers []string
+}
+
+// NewHibernateCommand creates a new cobra.Command for `docker container hibernate`
+func NewHibernateCommand(dockerCli command.Cli) *cobra.Command {
+	var opts hibernateOptions
+
+	cmd := &cobra.Command{
+		Use:   "hibernate CONTAINER [CONTAINER...]",
+		Short: "Freeze one or more containers and swap their memory out",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.containers = args
+			return runHibernate(dockerCli, &opts)
+		},
+		Annotations: map[string]string{"version": "1.39"},
+	}
+	return cmd
+}
+
+func runHibernate(dockerCli command.Cli, opts *hibernateOptions) error {
+	ctx := context.Background()
+
+	var errs []string
+	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerHibernate)
+	for _, container := range opts.containers {
+		if err := <-errChan; err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		fmt.Fprintln(dockerCli.Out(), container)
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
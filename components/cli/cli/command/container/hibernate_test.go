@@ -0,0 +1,46 @@
+package container
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestContainerHibernate(t *testing.T) {
+	var hibernated []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerHibernateFunc: func(container string) error {
+			if container == "c2" {
+				return errors.Errorf("Container %s is not running", container)
+			}
+			hibernated = append(hibernated, container)
+			return nil
+		},
+	})
+	cmd := NewHibernateCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"c1", "c2"})
+	assert.Error(t, cmd.Execute(), "Container c2 is not running")
+	assert.Check(t, is.DeepEqual([]string{"c1"}, hibernated))
+	assert.Check(t, is.Equal("c1\n", cli.OutBuffer().String()))
+}
+
+func TestContainerResume(t *testing.T) {
+	var resumed []string
+	cli := test.NewFakeCli(&fakeClient{
+		containerResumeFunc: func(container string) error {
+			resumed = append(resumed, container)
+			return nil
+		},
+	})
+	cmd := NewResumeCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"c1"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.DeepEqual([]string{"c1"}, resumed))
+	assert.Check(t, is.Equal("c1\n", cli.OutBuffer().String()))
+}
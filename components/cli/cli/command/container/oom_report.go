@@ -0,0 +1,103 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/templates"
+	"github.com/docker/docker/api/types"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type oomReportOptions struct {
+	container string
+	format    string
+}
+
+// NewOOMReportCommand creates a new cobra.Command for `docker container oom-report`
+func NewOOMReportCommand(dockerCli command.Cli) *cobra.Command {
+	var opts oomReportOptions
+
+	cmd := &cobra.Command{
+		Use:   "oom-report [OPTIONS] CONTAINER",
+		Short: "Display the memory snapshot taken when a container last ran out of memory",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runOOMReport(dockerCli, &opts)
+		},
+		Annotations: map[string]string{"version": "1.39"},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+	return cmd
+}
+
+func runOOMReport(dockerCli command.Cli, opts *oomReportOptions) error {
+	c, err := dockerCli.Client().ContainerInspect(context.Background(), opts.container)
+	if err != nil {
+		return err
+	}
+	if c.State == nil || c.State.OOMReport == nil {
+		return errors.Errorf("container %s has not run out of memory", opts.container)
+	}
+	report := c.State.OOMReport
+
+	if opts.format != "" {
+		tmpl, err := templates.Parse(opts.format)
+		if err != nil {
+			return cli.StatusError{StatusCode: 64,
+				Status: "Template parsing error: " + err.Error()}
+		}
+		err = tmpl.Execute(dockerCli.Out(), report)
+		dockerCli.Out().Write([]byte{'\n'})
+		return err
+	}
+	printOOMReport(dockerCli.Out(), report)
+	return nil
+}
+
+func printOOMReport(out io.Writer, r *types.OOMReport) {
+	w := tabwriter.NewWriter(out, 0, 1, 3, ' ', 0)
+	fmt.Fprintf(w, "Time:\t%s\n", r.Time.Format(time.RFC3339))
+	fmt.Fprintf(w, "Memory usage:\t%s / %s\n", units.BytesSize(float64(r.MemoryStats.Usage)), units.BytesSize(float64(r.MemoryStats.Limit)))
+	fmt.Fprintf(w, "Swap usage:\t%s / %s\n", units.BytesSize(float64(r.SwapStats.Usage)), units.BytesSize(float64(r.SwapStats.Limit)))
+	if r.SwapStats.Swapfile != "" {
+		fmt.Fprintf(w, "Swap area:\t%s (%s / %s used)\n", r.SwapStats.Swapfile, units.BytesSize(float64(r.SwapStats.SwapfileUsed)), units.BytesSize(float64(r.SwapStats.SwapfileSize)))
+	}
+	w.Flush()
+
+	if len(r.Processes) > 0 {
+		fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 1, 3, ' ', 0)
+		fmt.Fprintln(w, "PID\tRSS\tCOMMAND")
+		for _, p := range r.Processes {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", p.PID, units.BytesSize(float64(p.RSS)), p.Command)
+		}
+		w.Flush()
+	}
+
+	if len(r.MemoryStats.Stats) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Memory stats:")
+		keys := make([]string, 0, len(r.MemoryStats.Stats))
+		for k := range r.MemoryStats.Stats {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w = tabwriter.NewWriter(out, 0, 1, 3, ' ', 0)
+		for _, k := range keys {
+			fmt.Fprintf(w, " %s\t%d\n", k, r.MemoryStats.Stats[k])
+		}
+		w.Flush()
+	}
+}
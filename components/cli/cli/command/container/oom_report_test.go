@@ -0,0 +1,65 @@
+package container
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestContainerOOMReport(t *testing.T) {
+	report := &types.OOMReport{
+		Time:        time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC),
+		MemoryStats: types.MemoryStats{Usage: 512 * 1024 * 1024, Limit: 512 * 1024 * 1024, Stats: map[string]uint64{"rss": 1024, "cache": 2048}},
+		SwapStats:   types.SwapStats{Usage: 0, Limit: 1024 * 1024 * 1024, Swapfile: "/swap/web", SwapfileSize: 1024 * 1024 * 1024, SwapfileUsed: 512 * 1024 * 1024},
+		Processes: []types.OOMProcess{
+			{PID: 42, Command: "java", RSS: 500 * 1024 * 1024},
+			{PID: 1, Command: "sh", RSS: 1024 * 1024},
+		},
+	}
+	cli := test.NewFakeCli(&fakeClient{
+		inspectFunc: func(container string) (types.ContainerJSON, error) {
+			state := &types.ContainerState{}
+			if container == "web" {
+				state.OOMKilled = true
+				state.OOMReport = report
+			}
+			return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: state}}, nil
+		},
+	})
+
+	cmd := NewOOMReportCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"web"})
+	assert.NilError(t, cmd.Execute())
+	expected := `Time:           2018-06-01T12:00:00Z
+Memory usage:   512MiB / 512MiB
+Swap usage:     0B / 1GiB
+Swap area:      /swap/web (512MiB / 1GiB used)
+
+PID   RSS      COMMAND
+42    500MiB   java
+1     1MiB     sh
+
+Memory stats:
+ cache   2048
+ rss     1024
+`
+	assert.Check(t, is.Equal(expected, cli.OutBuffer().String()))
+
+	cli.OutBuffer().Reset()
+	cmd = NewOOMReportCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"--format", "{{(index .Processes 0).Command}}", "web"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("java\n", cli.OutBuffer().String()))
+
+	cmd = NewOOMReportCommand(cli)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"db"})
+	assert.Error(t, cmd.Execute(), "container db has not run out of memory")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/go-connections/nat"
+	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -37,6 +38,8 @@ type containerOptions struct {
 	deviceReadBps      opts.ThrottledeviceOpt
 	deviceWriteBps     opts.ThrottledeviceOpt
 	links              opts.ListOpts
+	dependsOn          opts.ListOpts
+	hooks              opts.ListOpts
 	aliases            opts.ListOpts
 	linkLocalIPs       opts.ListOpts
 	deviceReadIOps     opts.ThrottledeviceOpt
@@ -94,7 +97,9 @@ type containerOptions struct {
 	ioMaxBandwidth     opts.MemBytes
 	ioMaxIOps          uint64
 	swappiness         int64
-	swapfile		   string
+	swapfile           string
+	swapfileSize       opts.MemBytes
+	swapClass          string
 	netMode            string
 	macAddress         string
 	ipv4Address        string
@@ -112,14 +117,22 @@ type containerOptions struct {
 	shmSize            opts.MemBytes
 	noHealthcheck      bool
 	healthCmd          string
+	healthHTTPGet      string
+	healthTCP          string
+	healthGRPC         string
 	healthInterval     time.Duration
 	healthTimeout      time.Duration
 	healthStartPeriod  time.Duration
 	healthRetries      int
 	runtime            string
+	idlePolicy         string
+	memoryAutoscale    string
 	autoRemove         bool
 	init               bool
 
+	healthStartupCmd      string
+	healthStartupInterval time.Duration
+
 	Image string
 	Args  []string
 }
@@ -150,6 +163,8 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 		labelsFile:        opts.NewListOpts(nil),
 		linkLocalIPs:      opts.NewListOpts(nil),
 		links:             opts.NewListOpts(opts.ValidateLink),
+		dependsOn:         opts.NewListOpts(nil),
+		hooks:             opts.NewListOpts(nil),
 		loggingOpts:       opts.NewListOpts(nil),
 		publish:           opts.NewListOpts(nil),
 		securityOpt:       opts.NewListOpts(nil),
@@ -205,6 +220,8 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.StringVar(&copts.ipv4Address, "ip", "", "IPv4 address (e.g., 172.30.100.104)")
 	flags.StringVar(&copts.ipv6Address, "ip6", "", "IPv6 address (e.g., 2001:db8::33)")
 	flags.Var(&copts.links, "link", "Add link to another container")
+	flags.Var(&copts.dependsOn, "depends-on", "Start another container before this one (name[:started|healthy|completed])")
+	flags.SetAnnotation("depends-on", "version", []string{"1.39"})
 	flags.Var(&copts.linkLocalIPs, "link-local-ip", "Container IPv4/IPv6 link-local addresses")
 	flags.StringVar(&copts.macAddress, "mac-address", "", "Container MAC address (e.g., 92:d0:c6:0a:29:33)")
 	flags.VarP(&copts.publish, "publish", "p", "Publish a container's port(s) to the host")
@@ -230,11 +247,21 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 
 	// Health-checking
 	flags.StringVar(&copts.healthCmd, "health-cmd", "", "Command to run to check health")
+	flags.StringVar(&copts.healthHTTPGet, "health-http-get", "", "Check health with an HTTP GET request to a port of the container (port[/path])")
+	flags.SetAnnotation("health-http-get", "version", []string{"1.39"})
+	flags.StringVar(&copts.healthTCP, "health-tcp", "", "Check health by connecting to a port of the container")
+	flags.SetAnnotation("health-tcp", "version", []string{"1.39"})
+	flags.StringVar(&copts.healthGRPC, "health-grpc", "", "Check health with the gRPC health service on a port of the container (port[/service])")
+	flags.SetAnnotation("health-grpc", "version", []string{"1.39"})
 	flags.DurationVar(&copts.healthInterval, "health-interval", 0, "Time between running the check (ms|s|m|h) (default 0s)")
 	flags.IntVar(&copts.healthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy")
 	flags.DurationVar(&copts.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ms|s|m|h) (default 0s)")
 	flags.DurationVar(&copts.healthStartPeriod, "health-start-period", 0, "Start period for the container to initialize before starting health-retries countdown (ms|s|m|h) (default 0s)")
 	flags.SetAnnotation("health-start-period", "version", []string{"1.29"})
+	flags.StringVar(&copts.healthStartupCmd, "health-startup-cmd", "", "Command to run until it first succeeds, before the health check starts")
+	flags.SetAnnotation("health-startup-cmd", "version", []string{"1.39"})
+	flags.DurationVar(&copts.healthStartupInterval, "health-startup-interval", 0, "Time between running the startup check (ms|s|m|h) (default 0s)")
+	flags.SetAnnotation("health-startup-interval", "version", []string{"1.39"})
 	flags.BoolVar(&copts.noHealthcheck, "no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
 
 	// Resource management
@@ -269,10 +296,17 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.memoryReservation, "memory-reservation", "Memory soft limit")
 	flags.Var(&copts.memorySwap, "memory-swap", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
 	flags.Int64Var(&copts.swappiness, "memory-swappiness", -1, "Tune container memory swappiness (0 to 100)")
-	flags.StringVar(&copts.swapfile, "memory-swapfile", "default", "Tune container memory swapfile")
+	flags.StringVar(&copts.swapfile, "memory-swapfile", "", "Tune container memory swapfile ('auto' for a daemon-managed swapfile)")
+	flags.Var(&copts.swapfileSize, "memory-swapfile-size", "Size of the daemon-managed swapfile (with --memory-swapfile auto)")
+	flags.StringVar(&copts.swapClass, "memory-swap-class", "", "Place the container on the least utilised swap area of a daemon swap class")
+	flags.SetAnnotation("memory-swap-class", "version", []string{"1.39"})
 	flags.BoolVar(&copts.oomKillDisable, "oom-kill-disable", false, "Disable OOM Killer")
 	flags.IntVar(&copts.oomScoreAdj, "oom-score-adj", 0, "Tune host's OOM preferences (-1000 to 1000)")
 	flags.Int64Var(&copts.pidsLimit, "pids-limit", 0, "Tune container pids limit (set -1 for unlimited)")
+	flags.StringVar(&copts.idlePolicy, "idle-policy", "", "Lower the memory limit while the container is idle (window=<duration>,cpu=<percent>,network=<bytes>[,memory=<bytes>])")
+	flags.SetAnnotation("idle-policy", "version", []string{"1.39"})
+	flags.StringVar(&copts.memoryAutoscale, "memory-autoscale", "", "Adjust the memory limit to the memory usage (min=<bytes>,max=<bytes>,target=<percent>)")
+	flags.SetAnnotation("memory-autoscale", "version", []string{"1.39"})
 
 	// Low-level execution (cgroups, namespaces, ...)
 	flags.StringVar(&copts.cgroupParent, "cgroup-parent", "", "Optional parent cgroup for the container")
@@ -282,6 +316,8 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.shmSize, "shm-size", "Size of /dev/shm")
 	flags.StringVar(&copts.utsMode, "uts", "", "UTS namespace to use")
 	flags.StringVar(&copts.runtime, "runtime", "", "Runtime to use for this container")
+	flags.Var(&copts.hooks, "hook", "Run a hook on a container lifecycle event (phase=<prestart|poststart|poststop>,path=<path>[,arg=<arg>][,env=<var>][,timeout=<duration>][,in-container])")
+	flags.SetAnnotation("hook", "version", []string{"1.39"})
 
 	flags.BoolVar(&copts.init, "init", false, "Run an init inside the container that forwards signals and reaps processes")
 	flags.SetAnnotation("init", "version", []string{"1.25"})
@@ -327,6 +363,13 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		return nil, errors.Errorf("invalid value: %d. Valid memory swappiness range is 0-100", swappiness)
 	}
 
+	// Only send the swapfile if set, so that the daemon can apply its own
+	// default and does not reject it on kernels without swapfile support.
+	var swapfile *string
+	if copts.swapfile != "" {
+		swapfile = &copts.swapfile
+	}
+
 	mounts := copts.mounts.Value()
 	if len(mounts) > 0 && copts.volumeDriver != "" {
 		logrus.Warn("`--volume-driver` is ignored for volumes specified via `--mount`. Use `--mount type=volume,volume-driver=...` instead.")
@@ -476,10 +519,15 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 	// Healthcheck
 	var healthConfig *container.HealthConfig
 	haveHealthSettings := copts.healthCmd != "" ||
+		copts.healthHTTPGet != "" ||
+		copts.healthTCP != "" ||
+		copts.healthGRPC != "" ||
 		copts.healthInterval != 0 ||
 		copts.healthTimeout != 0 ||
 		copts.healthStartPeriod != 0 ||
-		copts.healthRetries != 0
+		copts.healthRetries != 0 ||
+		copts.healthStartupCmd != "" ||
+		copts.healthStartupInterval != 0
 	if copts.noHealthcheck {
 		if haveHealthSettings {
 			return nil, errors.Errorf("--no-healthcheck conflicts with --health-* options")
@@ -487,10 +535,9 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		test := strslice.StrSlice{"NONE"}
 		healthConfig = &container.HealthConfig{Test: test}
 	} else if haveHealthSettings {
-		var probe strslice.StrSlice
-		if copts.healthCmd != "" {
-			args := []string{"CMD-SHELL", copts.healthCmd}
-			probe = strslice.StrSlice(args)
+		probe, err := parseHealthProbe(copts)
+		if err != nil {
+			return nil, err
 		}
 		if copts.healthInterval < 0 {
 			return nil, errors.Errorf("--health-interval cannot be negative")
@@ -504,13 +551,20 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		if copts.healthStartPeriod < 0 {
 			return nil, fmt.Errorf("--health-start-period cannot be negative")
 		}
+		if copts.healthStartupInterval < 0 {
+			return nil, errors.Errorf("--health-startup-interval cannot be negative")
+		}
 
 		healthConfig = &container.HealthConfig{
-			Test:        probe,
-			Interval:    copts.healthInterval,
-			Timeout:     copts.healthTimeout,
-			StartPeriod: copts.healthStartPeriod,
-			Retries:     copts.healthRetries,
+			Test:            probe,
+			Interval:        copts.healthInterval,
+			Timeout:         copts.healthTimeout,
+			StartPeriod:     copts.healthStartPeriod,
+			Retries:         copts.healthRetries,
+			StartupInterval: copts.healthStartupInterval,
+		}
+		if copts.healthStartupCmd != "" {
+			healthConfig.StartupTest = []string{"CMD-SHELL", copts.healthStartupCmd}
 		}
 	}
 
@@ -520,7 +574,9 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		MemoryReservation:    copts.memoryReservation.Value(),
 		MemorySwap:           copts.memorySwap.Value(),
 		MemorySwappiness:     &copts.swappiness,
-		MemorySwapfile:		  &copts.swapfile,
+		MemorySwapfile:       swapfile,
+		MemorySwapfileSize:   copts.swapfileSize.Value(),
+		MemorySwapClass:      copts.swapClass,
 		KernelMemory:         copts.kernelMemory.Value(),
 		OomKillDisable:       &copts.oomKillDisable,
 		NanoCPUs:             copts.cpus.Value(),
@@ -619,6 +675,37 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		Mounts:         mounts,
 	}
 
+	if copts.idlePolicy != "" {
+		hostConfig.IdlePolicy, err = parseIdlePolicy(copts.idlePolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if copts.memoryAutoscale != "" {
+		hostConfig.MemoryAutoscale, err = parseMemoryAutoscale(copts.memoryAutoscale)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, dep := range copts.dependsOn.GetAll() {
+		dependency, err := parseDependency(dep)
+		if err != nil {
+			return nil, err
+		}
+		hostConfig.DependsOn = append(hostConfig.DependsOn, dependency)
+	}
+
+	for _, h := range copts.hooks.GetAll() {
+		if hostConfig.Hooks == nil {
+			hostConfig.Hooks = &container.Hooks{}
+		}
+		if err := parseHook(hostConfig.Hooks, h); err != nil {
+			return nil, err
+		}
+	}
+
 	if copts.autoRemove && !hostConfig.RestartPolicy.IsNone() {
 		return nil, errors.Errorf("Conflicting options: --restart and --rm")
 	}
@@ -745,6 +832,199 @@ func parseStorageOpts(storageOpts []string) (map[string]string, error) {
 	return m, nil
 }
 
+// parseIdlePolicy parses an idle policy in the
+// "window=<duration>,cpu=<percent>,network=<bytes>[,memory=<bytes>]" form
+func parseIdlePolicy(policy string) (*container.IdlePolicy, error) {
+	p := &container.IdlePolicy{}
+	for _, field := range strings.Split(policy, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, errors.Errorf("invalid idle policy field %q: must be a key=value pair", field)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+		var err error
+		switch key {
+		case "window":
+			p.Window, err = time.ParseDuration(value)
+		case "cpu":
+			p.CPUPercent, err = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		case "network":
+			p.NetworkBytes, err = units.RAMInBytes(value)
+		case "memory":
+			p.MemoryLimit, err = units.RAMInBytes(value)
+		default:
+			return nil, errors.Errorf("invalid idle policy key %q", key)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid idle policy %s", key)
+		}
+	}
+	if p.Window == 0 {
+		return nil, errors.New("invalid idle policy: window is required")
+	}
+	return p, nil
+}
+
+// parseHealthProbe returns the test of the healthcheck set by the
+// --health-cmd, --health-http-get, --health-tcp or --health-grpc option, if any
+func parseHealthProbe(copts *containerOptions) (strslice.StrSlice, error) {
+	var probe strslice.StrSlice
+	set := 0
+	if copts.healthCmd != "" {
+		probe = strslice.StrSlice{"CMD-SHELL", copts.healthCmd}
+		set++
+	}
+	if copts.healthHTTPGet != "" {
+		port, path := splitProbeTarget(copts.healthHTTPGet)
+		probe = strslice.StrSlice{"HTTP-GET", port}
+		if path != "" {
+			probe = append(probe, "/"+path)
+		}
+		set++
+	}
+	if copts.healthTCP != "" {
+		probe = strslice.StrSlice{"TCP", copts.healthTCP}
+		set++
+	}
+	if copts.healthGRPC != "" {
+		port, service := splitProbeTarget(copts.healthGRPC)
+		probe = strslice.StrSlice{"GRPC", port}
+		if service != "" {
+			probe = append(probe, service)
+		}
+		set++
+	}
+	if set > 1 {
+		return nil, errors.New("--health-cmd, --health-http-get, --health-tcp and --health-grpc are mutually exclusive")
+	}
+	if len(probe) > 1 && probe[0] != "CMD-SHELL" {
+		if port, err := strconv.Atoi(probe[1]); err != nil || port < 1 || port > 65535 {
+			return nil, errors.Errorf("invalid healthcheck port %q", probe[1])
+		}
+	}
+	return probe, nil
+}
+
+// splitProbeTarget splits a "port[/path]" probe target
+func splitProbeTarget(target string) (string, string) {
+	parts := strings.SplitN(target, "/", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// parseDependency parses a container dependency in the
+// "name[:started|healthy|completed]" form
+func parseDependency(dep string) (container.Dependency, error) {
+	name, condition := dep, ""
+	if i := strings.LastIndex(dep, ":"); i >= 0 {
+		name, condition = dep[:i], dep[i+1:]
+	}
+	if name == "" {
+		return container.Dependency{}, errors.Errorf("invalid dependency %q: a container name is required", dep)
+	}
+	switch container.DependencyCondition(condition) {
+	case "", container.DependencyStarted, container.DependencyHealthy, container.DependencyCompleted:
+	default:
+		return container.Dependency{}, errors.Errorf("invalid dependency %q: condition must be one of started, healthy or completed", dep)
+	}
+	return container.Dependency{Container: name, Condition: container.DependencyCondition(condition)}, nil
+}
+
+// parseHook parses a container hook in the "phase=<prestart|poststart|poststop>,
+// path=<path>[,arg=<arg>][,env=<var>][,timeout=<duration>][,in-container]" form,
+// and adds it to the hooks of its phase
+func parseHook(hooks *container.Hooks, hook string) error {
+	var (
+		h     container.Hook
+		phase string
+	)
+	for _, field := range strings.Split(hook, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(kv[0])
+		if key == "in-container" {
+			if len(kv) == 1 {
+				h.InContainer = true
+				continue
+			}
+			inContainer, err := strconv.ParseBool(kv[1])
+			if err != nil {
+				return errors.Errorf("invalid hook in-container value %q", kv[1])
+			}
+			h.InContainer = inContainer
+			continue
+		}
+		if len(kv) != 2 || kv[1] == "" {
+			return errors.Errorf("invalid hook field %q: must be a key=value pair", field)
+		}
+		value := kv[1]
+		switch key {
+		case "phase":
+			phase = strings.ToLower(value)
+		case "path":
+			h.Path = value
+		case "arg":
+			h.Args = append(h.Args, value)
+		case "env":
+			h.Env = append(h.Env, value)
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil {
+				return errors.Wrap(err, "invalid hook timeout")
+			}
+			h.Timeout = timeout
+		default:
+			return errors.Errorf("invalid hook key %q", key)
+		}
+	}
+	if h.Path == "" {
+		return errors.Errorf("invalid hook %q: a path is required", hook)
+	}
+	switch phase {
+	case "prestart":
+		hooks.Prestart = append(hooks.Prestart, h)
+	case "poststart":
+		hooks.Poststart = append(hooks.Poststart, h)
+	case "poststop":
+		hooks.Poststop = append(hooks.Poststop, h)
+	default:
+		return errors.Errorf("invalid hook %q: phase must be one of prestart, poststart or poststop", hook)
+	}
+	return nil
+}
+
+// parseMemoryAutoscale parses a memory autoscale policy in the
+// "min=<bytes>,max=<bytes>,target=<percent>" form
+func parseMemoryAutoscale(policy string) (*container.MemoryAutoscale, error) {
+	p := &container.MemoryAutoscale{}
+	for _, field := range strings.Split(policy, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, errors.Errorf("invalid memory autoscale field %q: must be a key=value pair", field)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+		var err error
+		switch key {
+		case "min":
+			p.Min, err = units.RAMInBytes(value)
+		case "max":
+			p.Max, err = units.RAMInBytes(value)
+		case "target":
+			p.TargetPercent, err = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		default:
+			return nil, errors.Errorf("invalid memory autoscale key %q", key)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid memory autoscale %s", key)
+		}
+	}
+	if p.Min == 0 || p.Max == 0 || p.TargetPercent == 0 {
+		return nil, errors.New("invalid memory autoscale: min, max and target are required")
+	}
+	return p, nil
+}
+
 // parseDevice parses a device mapping string to a container.DeviceMapping struct
 func parseDevice(device string) (container.DeviceMapping, error) {
 	src := ""
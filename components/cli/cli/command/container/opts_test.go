@@ -253,6 +253,71 @@ func TestParseWithMemorySwap(t *testing.T) {
 	assert.Check(t, is.Equal(int64(-1), hostconfig.MemorySwap))
 }
 
+func TestParseWithMemorySwapfile(t *testing.T) {
+	_, hostconfig := mustParse(t, "--memory=64m")
+	assert.Check(t, is.Nil(hostconfig.MemorySwapfile))
+
+	_, hostconfig = mustParse(t, "--memory-swapfile=/swap/nvme0")
+	assert.Assert(t, hostconfig.MemorySwapfile != nil)
+	assert.Check(t, is.Equal("/swap/nvme0", *hostconfig.MemorySwapfile))
+}
+
+func TestParseWithMemorySwapClass(t *testing.T) {
+	_, hostconfig := mustParse(t, "--memory-swap-class=fast")
+	assert.Check(t, is.Equal("fast", hostconfig.MemorySwapClass))
+	assert.Check(t, is.Nil(hostconfig.MemorySwapfile))
+}
+
+func TestParseWithIdlePolicy(t *testing.T) {
+	_, hostconfig := mustParse(t, "")
+	assert.Check(t, is.Nil(hostconfig.IdlePolicy))
+
+	_, hostconfig = mustParse(t, "--idle-policy=window=10m,cpu=1.5%,network=1m,memory=64m")
+	assert.Check(t, is.DeepEqual(&container.IdlePolicy{
+		Window:       10 * time.Minute,
+		CPUPercent:   1.5,
+		NetworkBytes: 1024 * 1024,
+		MemoryLimit:  64 * 1024 * 1024,
+	}, hostconfig.IdlePolicy))
+
+	invalids := map[string]string{
+		"--idle-policy=cpu=1":                 "invalid idle policy: window is required",
+		"--idle-policy=window=10m,cpu":        `invalid idle policy field "cpu": must be a key=value pair`,
+		"--idle-policy=window=10m,disk=1m":    `invalid idle policy key "disk"`,
+		"--idle-policy=window=10x,cpu=1":      "invalid idle policy window",
+		"--idle-policy=window=10m,cpu=lots":   "invalid idle policy cpu",
+		"--idle-policy=window=10m,network=1z": "invalid idle policy network",
+	}
+	for args, expected := range invalids {
+		_, _, _, err := parseRun(strings.Split(args+" img cmd", " "))
+		assert.Check(t, is.ErrorContains(err, expected), args)
+	}
+}
+
+func TestParseWithMemoryAutoscale(t *testing.T) {
+	_, hostconfig := mustParse(t, "")
+	assert.Check(t, is.Nil(hostconfig.MemoryAutoscale))
+
+	_, hostconfig = mustParse(t, "--memory-autoscale=min=256m,max=4g,target=80%")
+	assert.Check(t, is.DeepEqual(&container.MemoryAutoscale{
+		Min:           256 * 1024 * 1024,
+		Max:           4 * 1024 * 1024 * 1024,
+		TargetPercent: 80,
+	}, hostconfig.MemoryAutoscale))
+
+	invalids := map[string]string{
+		"--memory-autoscale=min=256m,max=4g":             "invalid memory autoscale: min, max and target are required",
+		"--memory-autoscale=min=256m,max":                `invalid memory autoscale field "max": must be a key=value pair`,
+		"--memory-autoscale=min=256m,max=4g,step=1m":     `invalid memory autoscale key "step"`,
+		"--memory-autoscale=min=256z,max=4g,target=80":   "invalid memory autoscale min",
+		"--memory-autoscale=min=256m,max=4g,target=most": "invalid memory autoscale target",
+	}
+	for args, expected := range invalids {
+		_, _, _, err := parseRun(strings.Split(args+" img cmd", " "))
+		assert.Check(t, is.ErrorContains(err, expected), args)
+	}
+}
+
 func TestParseHostname(t *testing.T) {
 	validHostnames := map[string]string{
 		"hostname":    "hostname",
@@ -412,8 +477,12 @@ func TestRunFlagsParseShmSize(t *testing.T) {
 
 func TestParseRestartPolicy(t *testing.T) {
 	invalids := map[string]string{
-		"always:2:3":         "invalid restart policy format",
-		"on-failure:invalid": "maximum retry count must be an integer",
+		"always:2:3":                "invalid restart policy format",
+		"on-failure:invalid":        "maximum retry count must be an integer",
+		"always,on-healthy":         `invalid restart policy option "on-healthy"`,
+		"always,delay=soon":         `invalid restart policy delay: time: invalid duration "soon"`,
+		"always,backoff=1s":         `invalid restart policy option "backoff"`,
+		"unless-stopped,max-delay=": `invalid restart policy option "max-delay="`,
 	}
 	valids := map[string]container.RestartPolicy{
 		"": {},
@@ -425,6 +494,14 @@ func TestParseRestartPolicy(t *testing.T) {
 			Name:              "on-failure",
 			MaximumRetryCount: 1,
 		},
+		"on-failure:3,on-unhealthy,delay=1s,max-delay=5m,reset-window=1h": {
+			Name:              "on-failure",
+			MaximumRetryCount: 3,
+			InitialDelay:      time.Second,
+			MaxDelay:          5 * time.Minute,
+			ResetWindow:       time.Hour,
+			OnUnhealthy:       true,
+		},
 	}
 	for restart, expectedError := range invalids {
 		if _, _, _, err := parseRun([]string{fmt.Sprintf("--restart=%s", restart), "img", "cmd"}); err == nil || err.Error() != expectedError {
@@ -489,6 +566,106 @@ func TestParseHealth(t *testing.T) {
 	}
 }
 
+func TestParseHealthStartup(t *testing.T) {
+	config, _, _, err := parseRun([]string{"--health-cmd=/check", "--health-startup-cmd=/ready", "--health-startup-interval=2s", "img", "cmd"})
+	assert.NilError(t, err)
+	health := config.Healthcheck
+	assert.Check(t, is.DeepEqual([]string{"CMD-SHELL", "/check"}, []string(health.Test)))
+	assert.Check(t, is.DeepEqual([]string{"CMD-SHELL", "/ready"}, health.StartupTest))
+	assert.Check(t, is.Equal(2*time.Second, health.StartupInterval))
+
+	_, _, _, err = parseRun([]string{"--health-startup-interval=-1s", "img", "cmd"})
+	assert.Check(t, is.Error(err, "--health-startup-interval cannot be negative"))
+
+	_, _, _, err = parseRun([]string{"--no-healthcheck", "--health-startup-cmd=/ready", "img", "cmd"})
+	assert.Check(t, is.Error(err, "--no-healthcheck conflicts with --health-* options"))
+}
+
+func TestParseHealthProbes(t *testing.T) {
+	valids := map[string][]string{
+		"--health-http-get=8080":               {"HTTP-GET", "8080"},
+		"--health-http-get=8080/healthz/ready": {"HTTP-GET", "8080", "/healthz/ready"},
+		"--health-tcp=5432":                    {"TCP", "5432"},
+		"--health-grpc=50051":                  {"GRPC", "50051"},
+		"--health-grpc=50051/my.Service":       {"GRPC", "50051", "my.Service"},
+	}
+	for arg, expected := range valids {
+		config, _, _, err := parseRun([]string{arg, "img", "cmd"})
+		assert.NilError(t, err, arg)
+		assert.Check(t, is.DeepEqual(expected, []string(config.Healthcheck.Test)), arg)
+	}
+
+	invalids := map[string][]string{
+		"--health-cmd, --health-http-get, --health-tcp and --health-grpc are mutually exclusive": {"--health-cmd=true", "--health-tcp=5432"},
+		`invalid healthcheck port "http"`:                    {"--health-http-get=http/healthz"},
+		`invalid healthcheck port "0"`:                       {"--health-tcp=0"},
+		"--no-healthcheck conflicts with --health-* options": {"--no-healthcheck", "--health-grpc=50051"},
+	}
+	for expected, args := range invalids {
+		_, _, _, err := parseRun(append(args, "img", "cmd"))
+		assert.Check(t, is.Error(err, expected), args)
+	}
+}
+
+func TestParseDependsOn(t *testing.T) {
+	_, hostConfig, _, err := parseRun([]string{"--depends-on=db:healthy", "--depends-on=cache", "--depends-on=migrate:completed", "img", "cmd"})
+	assert.NilError(t, err)
+	expected := []container.Dependency{
+		{Container: "db", Condition: container.DependencyHealthy},
+		{Container: "cache"},
+		{Container: "migrate", Condition: container.DependencyCompleted},
+	}
+	assert.Check(t, is.DeepEqual(expected, hostConfig.DependsOn))
+
+	invalids := map[string]string{
+		"db:ready": `invalid dependency "db:ready": condition must be one of started, healthy or completed`,
+		":healthy": `invalid dependency ":healthy": a container name is required`,
+	}
+	for dep, expected := range invalids {
+		_, _, _, err := parseRun([]string{"--depends-on=" + dep, "img", "cmd"})
+		assert.Check(t, is.Error(err, expected), dep)
+	}
+}
+
+func TestParseHooks(t *testing.T) {
+	_, hostConfig, _, err := parseRun([]string{
+		"--hook=phase=prestart,path=/usr/local/bin/register,arg=--id,arg=app,env=FOO=bar,timeout=5s",
+		"--hook=phase=poststart,path=/warmup.sh,in-container",
+		"--hook=phase=poststop,path=/usr/local/bin/cleanup",
+		"img", "cmd",
+	})
+	assert.NilError(t, err)
+	expected := &container.Hooks{
+		Prestart: []container.Hook{{
+			Path:    "/usr/local/bin/register",
+			Args:    []string{"--id", "app"},
+			Env:     []string{"FOO=bar"},
+			Timeout: 5 * time.Second,
+		}},
+		Poststart: []container.Hook{{Path: "/warmup.sh", InContainer: true}},
+		Poststop:  []container.Hook{{Path: "/usr/local/bin/cleanup"}},
+	}
+	assert.Check(t, is.DeepEqual(expected, hostConfig.Hooks))
+
+	_, hostConfig, _, err = parseRun([]string{"img", "cmd"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(hostConfig.Hooks))
+
+	invalids := map[string]string{
+		"phase=prestart":                             `invalid hook "phase=prestart": a path is required`,
+		"phase=start,path=/bin/true":                 `invalid hook "phase=start,path=/bin/true": phase must be one of prestart, poststart or poststop`,
+		"path=/bin/true":                             `invalid hook "path=/bin/true": phase must be one of prestart, poststart or poststop`,
+		"phase=prestart,path=/bin/true,user=root":    `invalid hook key "user"`,
+		"phase=prestart,path":                        `invalid hook field "path": must be a key=value pair`,
+		"phase=prestart,path=/bin/true,timeout=5":    "invalid hook timeout",
+		"phase=poststart,path=/a,in-container=maybe": `invalid hook in-container value "maybe"`,
+	}
+	for hook, expected := range invalids {
+		_, _, _, err := parseRun([]string{"--hook=" + hook, "img", "cmd"})
+		assert.Check(t, is.ErrorContains(err, expected), hook)
+	}
+}
+
 func TestParseLoggingOpts(t *testing.T) {
 	// logging opts ko
 	if _, _, _, err := parseRun([]string{"--log-driver=none", "--log-opt=anything", "img", "cmd"}); err == nil || err.Error() != "invalid logging opts for driver none" {
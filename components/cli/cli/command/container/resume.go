@@ -0,0 +1,51 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type resumeOptions struct {
+	containers []string
+}
+
+// NewResumeCommand creates a new cobra.Command for `docker container resume`
+func NewResumeCommand(dockerCli command.Cli) *cobra.Command {
+	var opts resumeOptions
+
+	cmd := &cobra.Command{
+		Use:   "resume CONTAINER [CONTAINER...]",
+		Short: "Resume one or more hibernated containers",
+		Args:  cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.containers = args
+			return runResume(dockerCli, &opts)
+		},
+		Annotations: map[string]string{"version": "1.39"},
+	}
+	return cmd
+}
+
+func runResume(dockerCli command.Cli, opts *resumeOptions) error {
+	ctx := context.Background()
+
+	var errs []string
+	errChan := parallelOperation(ctx, opts.containers, dockerCli.Client().ContainerResume)
+	for _, container := range opts.containers {
+		if err := <-errChan; err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		fmt.Fprintln(dockerCli.Out(), container)
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
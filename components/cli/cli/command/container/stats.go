@@ -22,6 +22,7 @@ type statsOptions struct {
 	all        bool
 	noStream   bool
 	noTrunc    bool
+	paging     bool
 	format     string
 	containers []string
 }
@@ -45,6 +46,7 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate output")
 	flags.StringVar(&opts.format, "format", "", "Pretty-print images using a Go template")
+	flags.BoolVar(&opts.paging, "paging", false, "Show the swap-in, swap-out and major fault rates of containers")
 	return cmd
 }
 
@@ -52,6 +54,9 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 // This shows real-time information on CPU usage, memory usage, and network I/O.
 // nolint: gocyclo
 func runStats(dockerCli command.Cli, opts *statsOptions) error {
+	if opts.paging && opts.format != "" {
+		return errors.New("--paging and --format cannot be combined")
+	}
 	showAll := len(opts.containers) == 0
 	closeChan := make(chan error)
 
@@ -200,6 +205,9 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 		Output: dockerCli.Out(),
 		Format: formatter.NewStatsFormat(format, daemonOSType),
 	}
+	if opts.paging {
+		statsCtx.Format = formatter.NewPagingStatsFormat()
+	}
 	cleanScreen := func() {
 		if !opts.noStream {
 			fmt.Fprint(dockerCli.Out(), "\033[2J")
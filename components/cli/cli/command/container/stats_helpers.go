@@ -58,6 +58,7 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 		getFirst       bool
 		previousCPU    uint64
 		previousSystem uint64
+		previous       *types.StatsJSON // Only used on Linux
 		u              = make(chan error, 1)
 	)
 
@@ -84,6 +85,10 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 				memPercent, cpuPercent float64
 				blkRead, blkWrite      uint64 // Only used on Linux
 				mem, memLimit          float64
+				swap, swapLimit        float64 // Only used on Linux
+				swapPercent            float64 // Only used on Linux
+				swapIn, swapOut        float64 // Only used on Linux
+				majorFaults            float64 // Only used on Linux
 				pidsStatsCurrent       uint64
 			)
 
@@ -107,6 +112,11 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 				mem = calculateMemUsageUnixNoCache(v.MemoryStats)
 				memLimit = float64(v.MemoryStats.Limit)
 				memPercent = calculateMemPercentUnixNoCache(memLimit, mem)
+				swap = float64(v.SwapStats.Usage)
+				swapLimit = float64(v.SwapStats.Limit)
+				swapPercent = calculateSwapPercent(swapLimit, swap)
+				swapIn, swapOut, majorFaults = calculatePagingRates(previous, v)
+				previous = v
 				pidsStatsCurrent = v.PidsStats.Current
 			} else {
 				cpuPercent = calculateCPUPercentWindows(v)
@@ -122,6 +132,12 @@ func collect(ctx context.Context, s *formatter.ContainerStats, cli client.APICli
 				Memory:           mem,
 				MemoryPercentage: memPercent,
 				MemoryLimit:      memLimit,
+				Swap:             swap,
+				SwapLimit:        swapLimit,
+				SwapPercentage:   swapPercent,
+				SwapInRate:       swapIn,
+				SwapOutRate:      swapOut,
+				MajorFaultRate:   majorFaults,
 				NetworkRx:        netRx,
 				NetworkTx:        netTx,
 				BlockRead:        float64(blkRead),
@@ -237,3 +253,60 @@ func calculateMemPercentUnixNoCache(limit float64, usedNoCache float64) float64
 	}
 	return 0
 }
+
+func calculateSwapPercent(limit float64, used float64) float64 {
+	// SwapStats.Limit is 0 if the container cannot swap, or if the daemon
+	// does not report swap usage
+	if limit != 0 {
+		return used / limit * 100.0
+	}
+	return 0
+}
+
+// swapPageSize is the size of the pages counted by the pswpin and pswpout
+// counters of memory.stat.
+const swapPageSize = 4096
+
+// calculatePagingRates computes the per-second swap-in and swap-out rates, in
+// bytes, and the per-second major fault rate of a container between two
+// samples. All rates are 0 for the first sample.
+func calculatePagingRates(previous, v *types.StatsJSON) (swapIn, swapOut, majorFaults float64) {
+	if previous == nil {
+		return 0, 0, 0
+	}
+	elapsed := v.Read.Sub(previous.Read).Seconds()
+	if elapsed <= 0 {
+		return 0, 0, 0
+	}
+	prev, cur := previous.MemoryStats.Stats, v.MemoryStats.Stats
+
+	majorFaults = counterRate(prev, cur, "pgmajfault", elapsed)
+
+	if _, ok := cur["pswpin"]; ok {
+		swapIn = counterRate(prev, cur, "pswpin", elapsed) * swapPageSize
+		swapOut = counterRate(prev, cur, "pswpout", elapsed) * swapPageSize
+		return swapIn, swapOut, majorFaults
+	}
+	// Without swap counters, derive the rates from the change in swap usage.
+	delta := float64(v.SwapStats.Usage) - float64(previous.SwapStats.Usage)
+	if delta > 0 {
+		swapOut = delta / elapsed
+	} else {
+		swapIn = -delta / elapsed
+	}
+	return swapIn, swapOut, majorFaults
+}
+
+// counterRate returns the per-second increase of a memory.stat counter, or 0
+// if it is missing from either sample or was reset in between.
+func counterRate(prev, cur map[string]uint64, key string, elapsed float64) float64 {
+	p, ok := prev[key]
+	if !ok {
+		return 0
+	}
+	c, ok := cur[key]
+	if !ok || c < p {
+		return 0
+	}
+	return float64(c-p) / elapsed
+}
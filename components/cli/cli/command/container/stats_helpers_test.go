@@ -3,6 +3,7 @@ package container
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"gotest.tools/assert"
@@ -36,6 +37,59 @@ func TestCalculateMemPercentUnixNoCache(t *testing.T) {
 	})
 }
 
+func TestCalculateSwapPercent(t *testing.T) {
+	t.Run("Limit is set", func(t *testing.T) {
+		result := calculateSwapPercent(200.0, 50.0)
+		assert.Assert(t, inDelta(25.0, result, 1e-6))
+	})
+	t.Run("No limit, no swap data", func(t *testing.T) {
+		result := calculateSwapPercent(0.0, 50.0)
+		assert.Assert(t, inDelta(0.0, result, 1e-6))
+	})
+}
+
+func TestCalculatePagingRates(t *testing.T) {
+	now := time.Now()
+	sample := func(read time.Time, swapUsage uint64, stats map[string]uint64) *types.StatsJSON {
+		v := &types.StatsJSON{}
+		v.Read = read
+		v.MemoryStats.Stats = stats
+		v.SwapStats.Usage = swapUsage
+		return v
+	}
+
+	t.Run("First sample", func(t *testing.T) {
+		swapIn, swapOut, majorFaults := calculatePagingRates(nil, sample(now, 0, map[string]uint64{"pgmajfault": 10}))
+		assert.Assert(t, inDelta(0.0, swapIn+swapOut+majorFaults, 1e-6))
+	})
+	t.Run("Swap counters", func(t *testing.T) {
+		previous := sample(now, 0, map[string]uint64{"pgmajfault": 10, "pswpin": 100, "pswpout": 200})
+		v := sample(now.Add(2*time.Second), 0, map[string]uint64{"pgmajfault": 30, "pswpin": 110, "pswpout": 240})
+		swapIn, swapOut, majorFaults := calculatePagingRates(previous, v)
+		assert.Assert(t, inDelta(5*4096.0, swapIn, 1e-6))
+		assert.Assert(t, inDelta(20*4096.0, swapOut, 1e-6))
+		assert.Assert(t, inDelta(10.0, majorFaults, 1e-6))
+	})
+	t.Run("Swap usage", func(t *testing.T) {
+		previous := sample(now, 4096, map[string]uint64{"pgmajfault": 10})
+		v := sample(now.Add(time.Second), 8192, map[string]uint64{"pgmajfault": 10})
+		swapIn, swapOut, majorFaults := calculatePagingRates(previous, v)
+		assert.Assert(t, inDelta(0.0, swapIn, 1e-6))
+		assert.Assert(t, inDelta(4096.0, swapOut, 1e-6))
+		assert.Assert(t, inDelta(0.0, majorFaults, 1e-6))
+
+		swapIn, swapOut, _ = calculatePagingRates(v, sample(now.Add(3*time.Second), 0, nil))
+		assert.Assert(t, inDelta(4096.0, swapIn, 1e-6))
+		assert.Assert(t, inDelta(0.0, swapOut, 1e-6))
+	})
+	t.Run("Counter reset", func(t *testing.T) {
+		previous := sample(now, 0, map[string]uint64{"pgmajfault": 10})
+		v := sample(now.Add(time.Second), 0, map[string]uint64{"pgmajfault": 2})
+		_, _, majorFaults := calculatePagingRates(previous, v)
+		assert.Assert(t, inDelta(0.0, majorFaults, 1e-6))
+	})
+}
+
 func inDelta(x, y, delta float64) func() (bool, string) {
 	return func() (bool, string) {
 		diff := x - y
@@ -25,6 +25,8 @@ type updateOptions struct {
 	memory             opts.MemBytes
 	memoryReservation  opts.MemBytes
 	memorySwap         opts.MemSwapBytes
+	memorySwappiness   int64
+	memorySwapfile     string
 	kernelMemory       opts.MemBytes
 	restartPolicy      string
 	cpus               opts.NanoCPUs
@@ -63,6 +65,8 @@ func NewUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	flags.VarP(&options.memory, "memory", "m", "Memory limit")
 	flags.Var(&options.memoryReservation, "memory-reservation", "Memory soft limit")
 	flags.Var(&options.memorySwap, "memory-swap", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
+	flags.Int64Var(&options.memorySwappiness, "memory-swappiness", -1, "Tune container memory swappiness (0 to 100)")
+	flags.StringVar(&options.memorySwapfile, "memory-swapfile", "", "Tune container memory swapfile")
 	flags.Var(&options.kernelMemory, "kernel-memory", "Kernel memory limit")
 	flags.StringVar(&options.restartPolicy, "restart", "", "Restart policy to apply when a container exits")
 
@@ -87,6 +91,11 @@ func runUpdate(dockerCli command.Cli, options *updateOptions) error {
 		}
 	}
 
+	swappiness := options.memorySwappiness
+	if swappiness != -1 && (swappiness < 0 || swappiness > 100) {
+		return errors.Errorf("invalid value: %d. Valid memory swappiness range is 0-100", swappiness)
+	}
+
 	resources := containertypes.Resources{
 		BlkioWeight:        options.blkioWeight,
 		CpusetCpus:         options.cpusetCpus,
@@ -103,6 +112,14 @@ func runUpdate(dockerCli command.Cli, options *updateOptions) error {
 		NanoCPUs:           options.cpus.Value(),
 	}
 
+	if swappiness != -1 {
+		resources.MemorySwappiness = &swappiness
+	}
+
+	if options.memorySwapfile != "" {
+		resources.MemorySwapfile = &options.memorySwapfile
+	}
+
 	updateConfig := containertypes.UpdateConfig{
 		Resources:     resources,
 		RestartPolicy: restartPolicy,
@@ -10,17 +10,23 @@ import (
 
 const (
 	winOSType                  = "windows"
-	defaultStatsTableFormat    = "table {{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"
+	defaultStatsTableFormat    = "table {{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.SwapUsage}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDs}}"
 	winDefaultStatsTableFormat = "table {{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.NetIO}}\t{{.BlockIO}}"
+	pagingStatsTableFormat     = "table {{.ID}}\t{{.Name}}\t{{.MemUsage}}\t{{.SwapUsage}}\t{{.SwapIn}}\t{{.SwapOut}}\t{{.MajFaults}}"
 
 	containerHeader = "CONTAINER"
 	cpuPercHeader   = "CPU %"
 	netIOHeader     = "NET I/O"
 	blockIOHeader   = "BLOCK I/O"
-	memPercHeader   = "MEM %"             // Used only on Linux
-	winMemUseHeader = "PRIV WORKING SET"  // Used only on Windows
-	memUseHeader    = "MEM USAGE / LIMIT" // Used only on Linux
-	pidsHeader      = "PIDS"              // Used only on Linux
+	memPercHeader   = "MEM %"              // Used only on Linux
+	winMemUseHeader = "PRIV WORKING SET"   // Used only on Windows
+	memUseHeader    = "MEM USAGE / LIMIT"  // Used only on Linux
+	swapUseHeader   = "SWAP USAGE / LIMIT" // Used only on Linux
+	swapPercHeader  = "SWAP %"             // Used only on Linux
+	swapInHeader    = "SWAP IN"            // Used only on Linux
+	swapOutHeader   = "SWAP OUT"           // Used only on Linux
+	majFaultsHeader = "MAJ FAULTS"         // Used only on Linux
+	pidsHeader      = "PIDS"               // Used only on Linux
 )
 
 // StatsEntry represents represents the statistics data collected from a container
@@ -32,6 +38,12 @@ type StatsEntry struct {
 	Memory           float64 // On Windows this is the private working set
 	MemoryLimit      float64 // Not used on Windows
 	MemoryPercentage float64 // Not used on Windows
+	Swap             float64 // Not used on Windows
+	SwapLimit        float64 // Not used on Windows
+	SwapPercentage   float64 // Not used on Windows
+	SwapInRate       float64 // Not used on Windows
+	SwapOutRate      float64 // Not used on Windows
+	MajorFaultRate   float64 // Not used on Windows
 	NetworkRx        float64
 	NetworkTx        float64
 	BlockRead        float64
@@ -64,6 +76,12 @@ func (cs *ContainerStats) SetErrorAndReset(err error) {
 	cs.Memory = 0
 	cs.MemoryPercentage = 0
 	cs.MemoryLimit = 0
+	cs.Swap = 0
+	cs.SwapLimit = 0
+	cs.SwapPercentage = 0
+	cs.SwapInRate = 0
+	cs.SwapOutRate = 0
+	cs.MajorFaultRate = 0
 	cs.NetworkRx = 0
 	cs.NetworkTx = 0
 	cs.BlockRead = 0
@@ -109,6 +127,12 @@ func NewStatsFormat(source, osType string) Format {
 	return Format(source)
 }
 
+// NewPagingStatsFormat returns the table format showing how hard containers
+// are paging.
+func NewPagingStatsFormat() Format {
+	return Format(pagingStatsTableFormat)
+}
+
 // NewContainerStats returns a new ContainerStats entity and sets in it the given name
 func NewContainerStats(container string) *ContainerStats {
 	return &ContainerStats{StatsEntry: StatsEntry{Container: container}}
@@ -141,6 +165,11 @@ func ContainerStatsWrite(ctx Context, containerStats []StatsEntry, osType string
 		"CPUPerc":   cpuPercHeader,
 		"MemUsage":  memUsage,
 		"MemPerc":   memPercHeader,
+		"SwapUsage": swapUseHeader,
+		"SwapPerc":  swapPercHeader,
+		"SwapIn":    swapInHeader,
+		"SwapOut":   swapOutHeader,
+		"MajFaults": majFaultsHeader,
 		"NetIO":     netIOHeader,
 		"BlockIO":   blockIOHeader,
 		"PIDs":      pidsHeader,
@@ -202,6 +231,41 @@ func (c *containerStatsContext) MemPerc() string {
 	return fmt.Sprintf("%.2f%%", c.s.MemoryPercentage)
 }
 
+func (c *containerStatsContext) SwapUsage() string {
+	if c.s.IsInvalid || c.os == winOSType {
+		return fmt.Sprintf("-- / --")
+	}
+	return fmt.Sprintf("%s / %s", units.BytesSize(c.s.Swap), units.BytesSize(c.s.SwapLimit))
+}
+
+func (c *containerStatsContext) SwapPerc() string {
+	if c.s.IsInvalid || c.os == winOSType {
+		return fmt.Sprintf("--")
+	}
+	return fmt.Sprintf("%.2f%%", c.s.SwapPercentage)
+}
+
+func (c *containerStatsContext) SwapIn() string {
+	if c.s.IsInvalid || c.os == winOSType {
+		return fmt.Sprintf("--")
+	}
+	return units.HumanSizeWithPrecision(c.s.SwapInRate, 3) + "/s"
+}
+
+func (c *containerStatsContext) SwapOut() string {
+	if c.s.IsInvalid || c.os == winOSType {
+		return fmt.Sprintf("--")
+	}
+	return units.HumanSizeWithPrecision(c.s.SwapOutRate, 3) + "/s"
+}
+
+func (c *containerStatsContext) MajFaults() string {
+	if c.s.IsInvalid || c.os == winOSType {
+		return fmt.Sprintf("--")
+	}
+	return fmt.Sprintf("%.1f/s", c.s.MajorFaultRate)
+}
+
 func (c *containerStatsContext) NetIO() string {
 	if c.s.IsInvalid {
 		return fmt.Sprintf("--")
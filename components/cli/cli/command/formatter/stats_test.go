@@ -33,6 +33,20 @@ func TestContainerStatsContext(t *testing.T) {
 		{StatsEntry{Memory: 24, MemoryLimit: 30}, "", "24B / 30B", memUseHeader, ctx.MemUsage},
 		{StatsEntry{Memory: 24, MemoryLimit: 30, IsInvalid: true}, "", "-- / --", memUseHeader, ctx.MemUsage},
 		{StatsEntry{Memory: 24, MemoryLimit: 30}, "windows", "24B", winMemUseHeader, ctx.MemUsage},
+		{StatsEntry{Swap: 12, SwapLimit: 48}, "", "12B / 48B", swapUseHeader, ctx.SwapUsage},
+		{StatsEntry{Swap: 12, SwapLimit: 48, IsInvalid: true}, "", "-- / --", swapUseHeader, ctx.SwapUsage},
+		{StatsEntry{Swap: 12, SwapLimit: 48}, "windows", "-- / --", swapUseHeader, ctx.SwapUsage},
+		{StatsEntry{SwapPercentage: 25}, "", "25.00%", swapPercHeader, ctx.SwapPerc},
+		{StatsEntry{SwapPercentage: 25, IsInvalid: true}, "", "--", swapPercHeader, ctx.SwapPerc},
+		{StatsEntry{SwapPercentage: 25}, "windows", "--", swapPercHeader, ctx.SwapPerc},
+		{StatsEntry{SwapInRate: 2048}, "", "2.05kB/s", swapInHeader, ctx.SwapIn},
+		{StatsEntry{SwapInRate: 2048, IsInvalid: true}, "", "--", swapInHeader, ctx.SwapIn},
+		{StatsEntry{SwapInRate: 2048}, "windows", "--", swapInHeader, ctx.SwapIn},
+		{StatsEntry{SwapOutRate: 4096}, "", "4.1kB/s", swapOutHeader, ctx.SwapOut},
+		{StatsEntry{SwapOutRate: 4096, IsInvalid: true}, "", "--", swapOutHeader, ctx.SwapOut},
+		{StatsEntry{MajorFaultRate: 12.5}, "", "12.5/s", majFaultsHeader, ctx.MajFaults},
+		{StatsEntry{MajorFaultRate: 12.5, IsInvalid: true}, "", "--", majFaultsHeader, ctx.MajFaults},
+		{StatsEntry{MajorFaultRate: 12.5}, "windows", "--", majFaultsHeader, ctx.MajFaults},
 		{StatsEntry{PidsCurrent: 10}, "", "10", pidsHeader, ctx.PIDs},
 		{StatsEntry{PidsCurrent: 10, IsInvalid: true}, "", "--", pidsHeader, ctx.PIDs},
 		{StatsEntry{PidsCurrent: 10}, "windows", "--", pidsHeader, ctx.PIDs},
@@ -66,6 +80,20 @@ func TestContainerStatsContextWrite(t *testing.T) {
 			`MEM USAGE / LIMIT
 20B / 20B
 -- / --
+`,
+		},
+		{
+			Context{Format: "table {{.SwapUsage}}\t{{.SwapPerc}}"},
+			`SWAP USAGE / LIMIT   SWAP %
+10B / 40B            25.00%
+-- / --              --
+`,
+		},
+		{
+			Context{Format: NewPagingStatsFormat()},
+			`CONTAINER ID        NAME                MEM USAGE / LIMIT   SWAP USAGE / LIMIT   SWAP IN             SWAP OUT            MAJ FAULTS
+abcdef              foo                 20B / 20B           10B / 40B            0B/s                8.19kB/s            1.5/s
+                    --                  -- / --             -- / --              --                  --                  --
 `,
 		},
 		{
@@ -92,6 +120,11 @@ container2  --
 				Memory:           20,
 				MemoryLimit:      20,
 				MemoryPercentage: 20,
+				Swap:             10,
+				SwapLimit:        40,
+				SwapPercentage:   25,
+				SwapOutRate:      8192,
+				MajorFaultRate:   1.5,
 				NetworkRx:        20,
 				NetworkTx:        20,
 				BlockRead:        20,
@@ -0,0 +1,130 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	swaptypes "github.com/docker/docker/api/types/swap"
+	units "github.com/docker/go-units"
+)
+
+const (
+	defaultSwapQuietFormat = "{{.Name}}"
+	defaultSwapTableFormat = "table {{.Name}}\t{{.Path}}\t{{.Size}}\t{{.Used}}\t{{.Priority}}\t{{.Active}}\t{{.Containers}}"
+
+	swapUsedHeader     = "USED"
+	swapPriorityHeader = "PRIORITY"
+)
+
+// NewSwapFormat returns a format for use with a swap area Context
+func NewSwapFormat(source string, quiet bool) Format {
+	switch source {
+	case TableFormatKey:
+		if quiet {
+			return defaultSwapQuietFormat
+		}
+		return defaultSwapTableFormat
+	case RawFormatKey:
+		if quiet {
+			return `name: {{.Name}}`
+		}
+		return `name: {{.Name}}\npath: {{.Path}}\n`
+	}
+	return Format(source)
+}
+
+// SwapWrite writes formatted swap areas using the Context
+func SwapWrite(ctx Context, areas []swaptypes.Area) error {
+	render := func(format func(subContext subContext) error) error {
+		for _, area := range areas {
+			if err := format(&swapContext{a: area}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return ctx.Write(newSwapContext(), render)
+}
+
+type swapContext struct {
+	HeaderContext
+	a swaptypes.Area
+}
+
+func newSwapContext() *swapContext {
+	swapCtx := swapContext{}
+	swapCtx.header = map[string]string{
+		"Name":       nameHeader,
+		"Path":       pathHeader,
+		"Type":       typeHeader,
+		"Size":       sizeHeader,
+		"Used":       swapUsedHeader,
+		"Priority":   swapPriorityHeader,
+		"Active":     activeHeader,
+		"Containers": containersHeader,
+		"Labels":     labelsHeader,
+	}
+	return &swapCtx
+}
+
+func (c *swapContext) MarshalJSON() ([]byte, error) {
+	return marshalJSON(c)
+}
+
+func (c *swapContext) Name() string {
+	return c.a.Name
+}
+
+func (c *swapContext) Path() string {
+	return c.a.Path
+}
+
+func (c *swapContext) Type() string {
+	return c.a.Type
+}
+
+func (c *swapContext) Size() string {
+	if !c.a.Active && c.a.Size == 0 {
+		return "N/A"
+	}
+	return units.BytesSize(float64(c.a.Size))
+}
+
+func (c *swapContext) Used() string {
+	if !c.a.Active {
+		return "N/A"
+	}
+	return units.BytesSize(float64(c.a.Used))
+}
+
+func (c *swapContext) Priority() string {
+	return strconv.Itoa(c.a.Priority)
+}
+
+func (c *swapContext) Active() string {
+	return strconv.FormatBool(c.a.Active)
+}
+
+func (c *swapContext) Containers() string {
+	return strconv.Itoa(len(c.a.Containers))
+}
+
+func (c *swapContext) Labels() string {
+	if c.a.Labels == nil {
+		return ""
+	}
+
+	var joinLabels []string
+	for k, v := range c.a.Labels {
+		joinLabels = append(joinLabels, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(joinLabels, ",")
+}
+
+func (c *swapContext) Label(name string) string {
+	if c.a.Labels == nil {
+		return ""
+	}
+	return c.a.Labels[name]
+}
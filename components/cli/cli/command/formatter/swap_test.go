@@ -0,0 +1,101 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestSwapContext(t *testing.T) {
+	var ctx swapContext
+	cases := []struct {
+		swapCtx  swapContext
+		expValue string
+		call     func() string
+	}{
+		{swapContext{
+			a: swaptypes.Area{Name: "nvme0"},
+		}, "nvme0", ctx.Name},
+		{swapContext{
+			a: swaptypes.Area{Path: "/swap/nvme0"},
+		}, "/swap/nvme0", ctx.Path},
+		{swapContext{
+			a: swaptypes.Area{Size: 2 * 1024 * 1024 * 1024, Active: true},
+		}, "2GiB", ctx.Size},
+		{swapContext{
+			a: swaptypes.Area{},
+		}, "N/A", ctx.Size},
+		{swapContext{
+			a: swaptypes.Area{Used: 512 * 1024 * 1024, Active: true},
+		}, "512MiB", ctx.Used},
+		{swapContext{
+			a: swaptypes.Area{Used: 512 * 1024 * 1024},
+		}, "N/A", ctx.Used},
+		{swapContext{
+			a: swaptypes.Area{Priority: -2},
+		}, "-2", ctx.Priority},
+		{swapContext{
+			a: swaptypes.Area{Containers: []string{"a", "b"}},
+		}, "2", ctx.Containers},
+		{swapContext{
+			a: swaptypes.Area{Labels: map[string]string{"tier": "fast"}},
+		}, "tier=fast", ctx.Labels},
+	}
+
+	for _, c := range cases {
+		ctx = c.swapCtx
+		assert.Check(t, is.Equal(c.expValue, c.call()))
+	}
+}
+
+func TestSwapContextWrite(t *testing.T) {
+	cases := []struct {
+		context  Context
+		expected string
+	}{
+		{
+			Context{Format: NewSwapFormat("table", false)},
+			`NAME                PATH                SIZE                USED                PRIORITY            ACTIVE              CONTAINERS
+nvme0               /swap/nvme0         1GiB                0B                  10                  true                1
+hdd0                /swap/hdd0          N/A                 N/A                 -2                  false               0
+`,
+		},
+		{
+			Context{Format: NewSwapFormat("table", true)},
+			`nvme0
+hdd0
+`,
+		},
+		{
+			Context{Format: NewSwapFormat("raw", false)},
+			`name: nvme0
+path: /swap/nvme0
+
+name: hdd0
+path: /swap/hdd0
+
+`,
+		},
+		{
+			Context{Format: NewSwapFormat("{{.Name}} {{.Priority}}", false)},
+			`nvme0 10
+hdd0 -2
+`,
+		},
+	}
+
+	for _, testcase := range cases {
+		areas := []swaptypes.Area{
+			{Name: "nvme0", Path: "/swap/nvme0", Size: 1024 * 1024 * 1024, Priority: 10, Active: true, Containers: []string{"abc"}},
+			{Name: "hdd0", Path: "/swap/hdd0", Priority: -2},
+		}
+		out := bytes.NewBufferString("")
+		testcase.context.Output = out
+		err := SwapWrite(testcase.context, areas)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(testcase.expected, out.String()))
+	}
+}
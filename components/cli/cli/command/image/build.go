@@ -47,6 +47,8 @@ type buildOptions struct {
 	ulimits        *opts.UlimitOpt
 	memory         opts.MemBytes
 	memorySwap     opts.MemSwapBytes
+	swappiness     int64
+	swapfile       string
 	shmSize        opts.MemBytes
 	cpuShares      int64
 	cpuPeriod      int64
@@ -95,6 +97,7 @@ func newBuildOptions() buildOptions {
 		ulimits:    opts.NewUlimitOpt(&ulimits),
 		labels:     opts.NewListOpts(opts.ValidateEnv),
 		extraHosts: opts.NewListOpts(opts.ValidateExtraHost),
+		swappiness: -1,
 	}
 }
 
@@ -120,6 +123,10 @@ func NewBuildCommand(dockerCli command.Cli) *cobra.Command {
 	flags.StringVarP(&options.dockerfileName, "file", "f", "", "Name of the Dockerfile (Default is 'PATH/Dockerfile')")
 	flags.VarP(&options.memory, "memory", "m", "Memory limit")
 	flags.Var(&options.memorySwap, "memory-swap", "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
+	flags.Int64Var(&options.swappiness, "memory-swappiness", -1, "Tune the memory swappiness (0 to 100) of the build containers")
+	flags.SetAnnotation("memory-swappiness", "version", []string{"1.39"})
+	flags.StringVar(&options.swapfile, "memory-swapfile", "", "Swap area of the build containers (absolute path of an active swap area, or 'default')")
+	flags.SetAnnotation("memory-swapfile", "version", []string{"1.39"})
 	flags.Var(&options.shmSize, "shm-size", "Size of /dev/shm")
 	flags.Int64VarP(&options.cpuShares, "cpu-shares", "c", 0, "CPU shares (relative weight)")
 	flags.Int64Var(&options.cpuPeriod, "cpu-period", 0, "Limit the CPU CFS (Completely Fair Scheduler) period")
@@ -601,32 +608,38 @@ func replaceDockerfileForContentTrust(ctx context.Context, inputTarStream io.Rea
 
 func imageBuildOptions(dockerCli command.Cli, options buildOptions) types.ImageBuildOptions {
 	configFile := dockerCli.ConfigFile()
+	var swappiness *int64
+	if options.swappiness != -1 {
+		swappiness = &options.swappiness
+	}
 	return types.ImageBuildOptions{
-		Memory:         options.memory.Value(),
-		MemorySwap:     options.memorySwap.Value(),
-		Tags:           options.tags.GetAll(),
-		SuppressOutput: options.quiet,
-		NoCache:        options.noCache,
-		Remove:         options.rm,
-		ForceRemove:    options.forceRm,
-		PullParent:     options.pull,
-		Isolation:      container.Isolation(options.isolation),
-		CPUSetCPUs:     options.cpuSetCpus,
-		CPUSetMems:     options.cpuSetMems,
-		CPUShares:      options.cpuShares,
-		CPUQuota:       options.cpuQuota,
-		CPUPeriod:      options.cpuPeriod,
-		CgroupParent:   options.cgroupParent,
-		ShmSize:        options.shmSize.Value(),
-		Ulimits:        options.ulimits.GetList(),
-		BuildArgs:      configFile.ParseProxyConfig(dockerCli.Client().DaemonHost(), options.buildArgs.GetAll()),
-		Labels:         opts.ConvertKVStringsToMap(options.labels.GetAll()),
-		CacheFrom:      options.cacheFrom,
-		SecurityOpt:    options.securityOpt,
-		NetworkMode:    options.networkMode,
-		Squash:         options.squash,
-		ExtraHosts:     options.extraHosts.GetAll(),
-		Target:         options.target,
-		Platform:       options.platform,
+		Memory:           options.memory.Value(),
+		MemorySwap:       options.memorySwap.Value(),
+		MemorySwappiness: swappiness,
+		MemorySwapfile:   options.swapfile,
+		Tags:             options.tags.GetAll(),
+		SuppressOutput:   options.quiet,
+		NoCache:          options.noCache,
+		Remove:           options.rm,
+		ForceRemove:      options.forceRm,
+		PullParent:       options.pull,
+		Isolation:        container.Isolation(options.isolation),
+		CPUSetCPUs:       options.cpuSetCpus,
+		CPUSetMems:       options.cpuSetMems,
+		CPUShares:        options.cpuShares,
+		CPUQuota:         options.cpuQuota,
+		CPUPeriod:        options.cpuPeriod,
+		CgroupParent:     options.cgroupParent,
+		ShmSize:          options.shmSize.Value(),
+		Ulimits:          options.ulimits.GetList(),
+		BuildArgs:        configFile.ParseProxyConfig(dockerCli.Client().DaemonHost(), options.buildArgs.GetAll()),
+		Labels:           opts.ConvertKVStringsToMap(options.labels.GetAll()),
+		CacheFrom:        options.cacheFrom,
+		SecurityOpt:      options.securityOpt,
+		NetworkMode:      options.networkMode,
+		Squash:           options.squash,
+		ExtraHosts:       options.extraHosts.GetAll(),
+		Target:           options.target,
+		Platform:         options.platform,
 	}
 }
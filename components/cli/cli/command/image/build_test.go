@@ -175,6 +175,21 @@ RUN echo hello world
 	assert.DeepEqual(t, fakeBuild.filenames(t), []string{"Dockerfile"})
 }
 
+func TestImageBuildOptionsMemorySwap(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{})
+
+	options := newBuildOptions()
+	buildOptions := imageBuildOptions(cli, options)
+	assert.Check(t, buildOptions.MemorySwappiness == nil)
+	assert.Equal(t, "", buildOptions.MemorySwapfile)
+
+	options.swappiness = 30
+	options.swapfile = "/mnt/ssd/swapfile"
+	buildOptions = imageBuildOptions(cli, options)
+	assert.Equal(t, int64(30), *buildOptions.MemorySwappiness)
+	assert.Equal(t, "/mnt/ssd/swapfile", buildOptions.MemorySwapfile)
+}
+
 func TestParseSecret(t *testing.T) {
 	type testcase struct {
 		value       string
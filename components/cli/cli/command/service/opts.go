@@ -227,6 +227,10 @@ type resourceOptions struct {
 	resCPU              opts.NanoCPUs
 	resMemBytes         opts.MemBytes
 	resGenericResources []string
+	memorySwap          opts.MemSwapBytes
+	memorySwappiness    int64
+	memorySwapfile      string
+	memorySwapfileSize  opts.MemBytes
 }
 
 func (r *resourceOptions) ToResourceRequirements() (*swarm.ResourceRequirements, error) {
@@ -235,6 +239,15 @@ func (r *resourceOptions) ToResourceRequirements() (*swarm.ResourceRequirements,
 		return nil, err
 	}
 
+	swappiness, err := toMemorySwappiness(r.memorySwappiness)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMemorySwapfile(r.memorySwapfile, r.memorySwapfileSize.Value()); err != nil {
+		return nil, err
+	}
+
 	return &swarm.ResourceRequirements{
 		Limits: &swarm.Resources{
 			NanoCPUs:    r.limitCPU.Value(),
@@ -245,9 +258,25 @@ func (r *resourceOptions) ToResourceRequirements() (*swarm.ResourceRequirements,
 			MemoryBytes:      r.resMemBytes.Value(),
 			GenericResources: generic,
 		},
+		MemorySwap:         r.memorySwap.Value(),
+		MemorySwappiness:   swappiness,
+		MemorySwapfile:     r.memorySwapfile,
+		MemorySwapfileSize: r.memorySwapfileSize.Value(),
 	}, nil
 }
 
+// validateMemorySwapfile checks that a swapfile size is given with, and only
+// with, a daemon-managed swapfile.
+func validateMemorySwapfile(swapfile string, size int64) error {
+	if swapfile == "auto" && size == 0 {
+		return errors.Errorf("--%s is required with --%s auto", flagMemorySwapfileSize, flagMemorySwapfile)
+	}
+	if swapfile != "auto" && size != 0 {
+		return errors.Errorf("--%s can only be used with --%s auto", flagMemorySwapfileSize, flagMemorySwapfile)
+	}
+	return nil
+}
+
 type restartPolicyOptions struct {
 	condition   string
 	delay       opts.DurationOpt
@@ -529,6 +558,7 @@ func newServiceOptions() *serviceOptions {
 		dnsOption:       opts.NewListOpts(nil),
 		dnsSearch:       opts.NewListOpts(opts.ValidateDNSSearch),
 		hosts:           opts.NewListOpts(opts.ValidateExtraHost),
+		resources:       resourceOptions{memorySwappiness: -1},
 	}
 }
 
@@ -551,6 +581,18 @@ func (options *serviceOptions) ToServiceMode() (swarm.ServiceMode, error) {
 	return serviceMode, nil
 }
 
+// toMemorySwappiness converts the value of the --memory-swappiness flag, where
+// -1 means the daemon default is used.
+func toMemorySwappiness(swappiness int64) (*int64, error) {
+	if swappiness == -1 {
+		return nil, nil
+	}
+	if swappiness < 0 || swappiness > 100 {
+		return nil, errors.Errorf("invalid value: %d. Valid memory swappiness range is 0-100", swappiness)
+	}
+	return &swappiness, nil
+}
+
 func (options *serviceOptions) ToStopGracePeriod(flags *pflag.FlagSet) *time.Duration {
 	if flags.Changed(flagStopGracePeriod) {
 		return options.stopGrace.Value()
@@ -809,6 +851,15 @@ func addServiceFlags(flags *pflag.FlagSet, opts *serviceOptions, defaultFlagValu
 	flags.SetAnnotation(flagStopSignal, "version", []string{"1.28"})
 	flags.StringVar(&opts.isolation, flagIsolation, "", "Service container isolation mode")
 	flags.SetAnnotation(flagIsolation, "version", []string{"1.35"})
+
+	flags.Var(&opts.resources.memorySwap, flagMemorySwap, "Swap limit equal to memory plus swap: '-1' to enable unlimited swap")
+	flags.SetAnnotation(flagMemorySwap, "version", []string{"1.39"})
+	flags.Int64Var(&opts.resources.memorySwappiness, flagMemorySwappiness, -1, "Tune container memory swappiness (0 to 100)")
+	flags.SetAnnotation(flagMemorySwappiness, "version", []string{"1.39"})
+	flags.StringVar(&opts.resources.memorySwapfile, flagMemorySwapfile, "", "Tune container memory swapfile ('auto' for a daemon-managed swapfile)")
+	flags.SetAnnotation(flagMemorySwapfile, "version", []string{"1.39"})
+	flags.Var(&opts.resources.memorySwapfileSize, flagMemorySwapfileSize, "Size of the daemon-managed swapfile (with --memory-swapfile auto)")
+	flags.SetAnnotation(flagMemorySwapfileSize, "version", []string{"1.39"})
 }
 
 const (
@@ -852,6 +903,10 @@ const (
 	flagLabelAdd                = "label-add"
 	flagLimitCPU                = "limit-cpu"
 	flagLimitMemory             = "limit-memory"
+	flagMemorySwap              = "memory-swap"
+	flagMemorySwappiness        = "memory-swappiness"
+	flagMemorySwapfile          = "memory-swapfile"
+	flagMemorySwapfileSize      = "memory-swapfile-size"
 	flagMode                    = "mode"
 	flagMount                   = "mount"
 	flagMountRemove             = "mount-rm"
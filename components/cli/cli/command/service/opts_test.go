@@ -224,3 +224,40 @@ func TestToServiceUpdateRollback(t *testing.T) {
 	assert.Check(t, is.DeepEqual(service.UpdateConfig, expected.UpdateConfig))
 	assert.Check(t, is.DeepEqual(service.RollbackConfig, expected.RollbackConfig))
 }
+
+func TestToServiceMemorySwap(t *testing.T) {
+	flags := newCreateCommand(nil).Flags()
+	flags.Set("memory-swap", "2g")
+	flags.Set("memory-swappiness", "10")
+	flags.Set("memory-swapfile", "auto")
+	flags.Set("memory-swapfile-size", "1g")
+
+	o := newServiceOptions()
+	o.mode = "replicated"
+	o.resources.memorySwap.Set("2g")
+	o.resources.memorySwappiness = 10
+	o.resources.memorySwapfile = "auto"
+	o.resources.memorySwapfileSize.Set("1g")
+
+	service, err := o.ToService(context.Background(), &fakeClient{}, flags)
+	assert.NilError(t, err)
+	resources := service.TaskTemplate.Resources
+	assert.Check(t, is.Equal(int64(2*1024*1024*1024), resources.MemorySwap))
+	assert.Check(t, is.Equal(int64(10), *resources.MemorySwappiness))
+	assert.Check(t, is.Equal("auto", resources.MemorySwapfile))
+	assert.Check(t, is.Equal(int64(1024*1024*1024), resources.MemorySwapfileSize))
+
+	o.resources.memorySwappiness = 200
+	_, err = o.ToService(context.Background(), &fakeClient{}, flags)
+	assert.Check(t, is.Error(err, "invalid value: 200. Valid memory swappiness range is 0-100"))
+
+	o.resources.memorySwappiness = -1
+	o.resources.memorySwapfileSize = 0
+	_, err = o.ToService(context.Background(), &fakeClient{}, flags)
+	assert.Check(t, is.Error(err, "--memory-swapfile-size is required with --memory-swapfile auto"))
+
+	o.resources.memorySwapfile = "/swapfile"
+	o.resources.memorySwapfileSize.Set("1g")
+	_, err = o.ToService(context.Background(), &fakeClient{}, flags)
+	assert.Check(t, is.Error(err, "--memory-swapfile-size can only be used with --memory-swapfile auto"))
+}
@@ -340,6 +340,30 @@ func updateService(ctx context.Context, apiClient client.NetworkAPIClient, flags
 		updateInt64Value(flagReserveMemory, &task.Resources.Reservations.MemoryBytes)
 	}
 
+	if anyChanged(flags, flagMemorySwap, flagMemorySwappiness, flagMemorySwapfile, flagMemorySwapfileSize) {
+		if task.Resources == nil {
+			task.Resources = &swarm.ResourceRequirements{}
+		}
+		updateInt64Value(flagMemorySwap, &task.Resources.MemorySwap)
+		if flags.Changed(flagMemorySwappiness) {
+			val, _ := flags.GetInt64(flagMemorySwappiness)
+			swappiness, err := toMemorySwappiness(val)
+			if err != nil {
+				return err
+			}
+			task.Resources.MemorySwappiness = swappiness
+		}
+		updateString(flagMemorySwapfile, &task.Resources.MemorySwapfile)
+		if task.Resources.MemorySwapfile != "auto" && !flags.Changed(flagMemorySwapfileSize) {
+			// the size of a daemon-managed swapfile no longer applies
+			task.Resources.MemorySwapfileSize = 0
+		}
+		updateInt64Value(flagMemorySwapfileSize, &task.Resources.MemorySwapfileSize)
+		if err := validateMemorySwapfile(task.Resources.MemorySwapfile, task.Resources.MemorySwapfileSize); err != nil {
+			return err
+		}
+	}
+
 	if err := addGenericResources(flags, task); err != nil {
 		return err
 	}
@@ -599,6 +599,59 @@ func TestUpdateStopSignal(t *testing.T) {
 	assert.Check(t, is.Equal("SIGWINCH", cspec.StopSignal))
 }
 
+func TestUpdateMemorySwap(t *testing.T) {
+	spec := &swarm.ServiceSpec{
+		TaskTemplate: swarm.TaskSpec{
+			ContainerSpec: &swarm.ContainerSpec{},
+		},
+	}
+
+	flags := newUpdateCommand(nil).Flags()
+	flags.Set("memory-swap", "-1")
+	flags.Set("memory-swappiness", "0")
+	flags.Set("memory-swapfile", "auto")
+	flags.Set("memory-swapfile-size", "1g")
+	assert.NilError(t, updateService(nil, nil, flags, spec))
+	resources := spec.TaskTemplate.Resources
+	assert.Check(t, is.Equal(int64(-1), resources.MemorySwap))
+	assert.Check(t, is.Equal(int64(0), *resources.MemorySwappiness))
+	assert.Check(t, is.Equal("auto", resources.MemorySwapfile))
+	assert.Check(t, is.Equal(int64(1024*1024*1024), resources.MemorySwapfileSize))
+
+	// Update without swap flags, no change
+	flags = newUpdateCommand(nil).Flags()
+	assert.NilError(t, updateService(nil, nil, flags, spec))
+	assert.Check(t, is.Equal(int64(-1), resources.MemorySwap))
+	assert.Check(t, is.Equal(int64(0), *resources.MemorySwappiness))
+	assert.Check(t, is.Equal("auto", resources.MemorySwapfile))
+	assert.Check(t, is.Equal(int64(1024*1024*1024), resources.MemorySwapfileSize))
+
+	// Switching to a swap area of the host drops the swapfile size
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("memory-swapfile", "/swapfile")
+	assert.NilError(t, updateService(nil, nil, flags, spec))
+	assert.Check(t, is.Equal("/swapfile", resources.MemorySwapfile))
+	assert.Check(t, is.Equal(int64(0), resources.MemorySwapfileSize))
+
+	// Reset swappiness and swapfile to the daemon defaults
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("memory-swappiness", "-1")
+	flags.Set("memory-swapfile", "")
+	assert.NilError(t, updateService(nil, nil, flags, spec))
+	assert.Check(t, is.Nil(resources.MemorySwappiness))
+	assert.Check(t, is.Equal("", resources.MemorySwapfile))
+
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("memory-swappiness", "101")
+	err := updateService(nil, nil, flags, spec)
+	assert.Check(t, is.Error(err, "invalid value: 101. Valid memory swappiness range is 0-100"))
+
+	flags = newUpdateCommand(nil).Flags()
+	flags.Set("memory-swapfile", "auto")
+	err = updateService(nil, nil, flags, spec)
+	assert.Check(t, is.Error(err, "--memory-swapfile-size is required with --memory-swapfile auto"))
+}
+
 func TestUpdateIsolationValid(t *testing.T) {
 	flags := newUpdateCommand(nil).Flags()
 	err := flags.Set("isolation", "process")
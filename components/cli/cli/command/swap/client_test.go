@@ -0,0 +1,54 @@
+package swap
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/docker/docker/client"
+)
+
+type fakeClient struct {
+	client.Client
+	swapCreateFunc  func(swaptypes.CreateOptions) (swaptypes.Area, error)
+	swapInspectFunc func(name string) (swaptypes.Area, error)
+	swapListFunc    func(filter filters.Args) ([]swaptypes.Area, error)
+	swapRemoveFunc  func(name string) error
+	swapPruneFunc   func(filter filters.Args) (types.SwapsPruneReport, error)
+}
+
+func (c *fakeClient) SwapCreate(ctx context.Context, options swaptypes.CreateOptions) (swaptypes.Area, error) {
+	if c.swapCreateFunc != nil {
+		return c.swapCreateFunc(options)
+	}
+	return swaptypes.Area{}, nil
+}
+
+func (c *fakeClient) SwapInspect(ctx context.Context, name string) (swaptypes.Area, error) {
+	if c.swapInspectFunc != nil {
+		return c.swapInspectFunc(name)
+	}
+	return swaptypes.Area{}, nil
+}
+
+func (c *fakeClient) SwapList(ctx context.Context, filter filters.Args) ([]swaptypes.Area, error) {
+	if c.swapListFunc != nil {
+		return c.swapListFunc(filter)
+	}
+	return nil, nil
+}
+
+func (c *fakeClient) SwapRemove(ctx context.Context, name string) error {
+	if c.swapRemoveFunc != nil {
+		return c.swapRemoveFunc(name)
+	}
+	return nil
+}
+
+func (c *fakeClient) SwapsPrune(ctx context.Context, filter filters.Args) (types.SwapsPruneReport, error) {
+	if c.swapPruneFunc != nil {
+		return c.swapPruneFunc(filter)
+	}
+	return types.SwapsPruneReport{}, nil
+}
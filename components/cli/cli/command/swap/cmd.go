@@ -0,0 +1,26 @@
+package swap
+
+import (
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewSwapCommand returns a cobra command for `swap` subcommands
+func NewSwapCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "swap COMMAND",
+		Short:       "Manage swap areas",
+		Args:        cli.NoArgs,
+		RunE:        command.ShowHelp(dockerCli.Err()),
+		Annotations: map[string]string{"version": "1.39", "ostype": "linux"},
+	}
+	cmd.AddCommand(
+		newCreateCommand(dockerCli),
+		newInspectCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+		NewPruneCommand(dockerCli),
+	)
+	return cmd
+}
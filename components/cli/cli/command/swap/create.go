@@ -0,0 +1,70 @@
+package swap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/opts"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	name     string
+	path     string
+	size     opts.MemBytes
+	priority int
+	labels   opts.ListOpts
+}
+
+func newCreateCommand(dockerCli command.Cli) *cobra.Command {
+	options := createOptions{
+		labels: opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] PATH",
+		Short: "Create a swap area",
+		Long:  createDescription,
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.path = args[0]
+			return runCreate(dockerCli, options)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&options.name, "name", "", "Specify swap area name")
+	flags.Var(&options.size, "size", "Size of the swap file to create")
+	flags.IntVar(&options.priority, "priority", -1, "Swap priority (-1 to let the kernel choose)")
+	flags.Var(&options.labels, "label", "Set metadata for a swap area")
+
+	return cmd
+}
+
+func runCreate(dockerCli command.Cli, options createOptions) error {
+	client := dockerCli.Client()
+
+	req := swaptypes.CreateOptions{
+		Name:     options.name,
+		Path:     options.path,
+		Size:     options.size.Value(),
+		Priority: &options.priority,
+		Labels:   opts.ConvertKVStringsToMap(options.labels.GetAll()),
+	}
+
+	area, err := client.SwapCreate(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", area.Name)
+	return nil
+}
+
+var createDescription = `
+Register a swap area with the daemon and activate it. PATH is either an
+existing swap file or block device, or, if --size is given, the swap file
+for the daemon to create.
+`
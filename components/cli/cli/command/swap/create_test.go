@@ -0,0 +1,81 @@
+package swap
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestSwapCreateErrors(t *testing.T) {
+	testCases := []struct {
+		args           []string
+		flags          map[string]string
+		swapCreateFunc func(swaptypes.CreateOptions) (swaptypes.Area, error)
+		expectedError  string
+	}{
+		{
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args:          []string{"/swap/a", "/swap/b"},
+			expectedError: "requires exactly 1 argument",
+		},
+		{
+			args: []string{"/swap/a"},
+			swapCreateFunc: func(options swaptypes.CreateOptions) (swaptypes.Area, error) {
+				return swaptypes.Area{}, errors.Errorf("error creating swap area")
+			},
+			expectedError: "error creating swap area",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newCreateCommand(
+			test.NewFakeCli(&fakeClient{
+				swapCreateFunc: tc.swapCreateFunc,
+			}),
+		)
+		cmd.SetArgs(tc.args)
+		for key, value := range tc.flags {
+			cmd.Flags().Set(key, value)
+		}
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestSwapCreateWithFlags(t *testing.T) {
+	expectedLabels := map[string]string{"tier": "fast"}
+
+	cli := test.NewFakeCli(&fakeClient{
+		swapCreateFunc: func(options swaptypes.CreateOptions) (swaptypes.Area, error) {
+			if options.Path != "/swap/nvme0" {
+				return swaptypes.Area{}, errors.Errorf("expected path /swap/nvme0, got %s", options.Path)
+			}
+			if options.Size != 1024*1024*1024 {
+				return swaptypes.Area{}, errors.Errorf("expected size 1GiB, got %d", options.Size)
+			}
+			if options.Priority == nil || *options.Priority != 10 {
+				return swaptypes.Area{}, errors.Errorf("expected priority 10, got %v", options.Priority)
+			}
+			if len(options.Labels) != 1 || options.Labels["tier"] != expectedLabels["tier"] {
+				return swaptypes.Area{}, errors.Errorf("expected labels %v, got %v", expectedLabels, options.Labels)
+			}
+			return swaptypes.Area{Name: options.Name}, nil
+		},
+	})
+
+	cmd := newCreateCommand(cli)
+	cmd.SetArgs([]string{"/swap/nvme0"})
+	cmd.Flags().Set("name", "nvme0")
+	cmd.Flags().Set("size", "1g")
+	cmd.Flags().Set("priority", "10")
+	cmd.Flags().Set("label", "tier=fast")
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal("nvme0", strings.TrimSpace(cli.OutBuffer().String())))
+}
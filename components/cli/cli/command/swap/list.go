@@ -0,0 +1,63 @@
+package swap
+
+import (
+	"context"
+	"sort"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/command/formatter"
+	"github.com/docker/cli/opts"
+	"github.com/spf13/cobra"
+	"vbom.ml/util/sortorder"
+)
+
+type listOptions struct {
+	quiet  bool
+	format string
+	filter opts.FilterOpt
+}
+
+func newListCommand(dockerCli command.Cli) *cobra.Command {
+	options := listOptions{filter: opts.NewFilterOpt()}
+
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List swap areas",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, options)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display swap area names")
+	flags.StringVar(&options.format, "format", "", "Pretty-print swap areas using a Go template")
+	flags.VarP(&options.filter, "filter", "f", "Provide filter values (e.g. 'dangling=true')")
+
+	return cmd
+}
+
+func runList(dockerCli command.Cli, options listOptions) error {
+	client := dockerCli.Client()
+	areas, err := client.SwapList(context.Background(), options.filter.Value())
+	if err != nil {
+		return err
+	}
+
+	format := options.format
+	if len(format) == 0 {
+		format = formatter.TableFormatKey
+	}
+
+	sort.Slice(areas, func(i, j int) bool {
+		return sortorder.NaturalLess(areas[i].Name, areas[j].Name)
+	})
+
+	swapCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: formatter.NewSwapFormat(format, options.quiet),
+	}
+	return formatter.SwapWrite(swapCtx, areas)
+}
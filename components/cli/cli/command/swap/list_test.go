@@ -0,0 +1,57 @@
+package swap
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	"gotest.tools/golden"
+)
+
+func TestSwapListErrors(t *testing.T) {
+	testCases := []struct {
+		args          []string
+		swapListFunc  func(filter filters.Args) ([]swaptypes.Area, error)
+		expectedError string
+	}{
+		{
+			args:          []string{"foo"},
+			expectedError: "accepts no argument",
+		},
+		{
+			swapListFunc: func(filter filters.Args) ([]swaptypes.Area, error) {
+				return nil, errors.Errorf("error listing swap areas")
+			},
+			expectedError: "error listing swap areas",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newListCommand(
+			test.NewFakeCli(&fakeClient{
+				swapListFunc: tc.swapListFunc,
+			}),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestSwapListSort(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		swapListFunc: func(filter filters.Args) ([]swaptypes.Area, error) {
+			return []swaptypes.Area{
+				{Name: "swap-10", Path: "/swap/10", Size: 1 << 30, Priority: 5, Active: true},
+				{Name: "swap-2", Path: "/swap/2", Size: 2 << 30, Used: 1 << 20, Priority: 10, Active: true, Containers: []string{"abc"}},
+				{Name: "hdd", Path: "/dev/sdb2", Priority: -2},
+			}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "swap-list-sort.golden")
+}
@@ -0,0 +1,56 @@
+package swap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/cli/cli"
+	"github.com/docker/cli/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newRemoveCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm SWAP [SWAP...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more swap areas",
+		Long:    removeDescription,
+		Example: removeExample,
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(dockerCli, args)
+		},
+	}
+}
+
+func runRemove(dockerCli command.Cli, names []string) error {
+	client := dockerCli.Client()
+	ctx := context.Background()
+
+	var errs []string
+
+	for _, name := range names {
+		if err := client.SwapRemove(ctx, name); err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\n", name)
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+var removeDescription = `
+Deactivate and remove one or more swap areas. Swap files created by the daemon
+are deleted. You cannot remove a swap area that is assigned to a container.
+`
+
+var removeExample = `
+$ docker swap rm nvme0
+nvme0
+`
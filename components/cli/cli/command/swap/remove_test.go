@@ -0,0 +1,44 @@
+package swap
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/cli/internal/test"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func TestSwapRemoveErrors(t *testing.T) {
+	testCases := []struct {
+		args           []string
+		swapRemoveFunc func(name string) error
+		expectedError  string
+	}{
+		{
+			expectedError: "requires at least 1 argument",
+		},
+		{
+			args: []string{"nvme0"},
+			swapRemoveFunc: func(name string) error {
+				return errors.Errorf("swap area nvme0 is in use by container(s) abc")
+			},
+			expectedError: "is in use by container(s) abc",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newRemoveCommand(
+			test.NewFakeCli(&fakeClient{
+				swapRemoveFunc: tc.swapRemoveFunc,
+			}))
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.ErrorContains(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestSwapRemoveMultiple(t *testing.T) {
+	cmd := newRemoveCommand(test.NewFakeCli(&fakeClient{}))
+	cmd.SetArgs([]string{"nvme0", "hdd0"})
+	assert.NilError(t, cmd.Execute())
+}
@@ -74,6 +74,7 @@ func prettyPrintInfo(dockerCli command.Cli, info types.Info) error {
 	}
 	fprintlnNonEmpty(dockerCli.Out(), "Logging Driver:", info.LoggingDriver)
 	fprintlnNonEmpty(dockerCli.Out(), "Cgroup Driver:", info.CgroupDriver)
+	fprintlnNonEmpty(dockerCli.Out(), "Cgroup Version:", info.CgroupVersion)
 
 	fmt.Fprintln(dockerCli.Out(), "Plugins:")
 	fmt.Fprintln(dockerCli.Out(), " Volume:", strings.Join(info.Plugins.Volume, " "))
@@ -146,6 +147,7 @@ func prettyPrintInfo(dockerCli command.Cli, info types.Info) error {
 	fprintlnNonEmpty(dockerCli.Out(), "Architecture:", info.Architecture)
 	fmt.Fprintln(dockerCli.Out(), "CPUs:", info.NCPU)
 	fmt.Fprintln(dockerCli.Out(), "Total Memory:", units.BytesSize(float64(info.MemTotal)))
+	printSwapInfo(dockerCli, info)
 	fprintlnNonEmpty(dockerCli.Out(), "Name:", info.Name)
 	fprintlnNonEmpty(dockerCli.Out(), "ID:", info.ID)
 	fmt.Fprintln(dockerCli.Out(), "Docker Root Dir:", info.DockerRootDir)
@@ -213,6 +215,23 @@ func prettyPrintInfo(dockerCli command.Cli, info types.Info) error {
 	return nil
 }
 
+func printSwapInfo(dockerCli command.Cli, info types.Info) {
+	// daemons that do not report swap areas leave the list unset
+	if info.SwapAreas == nil {
+		return
+	}
+	fmt.Fprintln(dockerCli.Out(), "Swapfile Support:", info.MemorySwapfile)
+	fmt.Fprintln(dockerCli.Out(), "Swap Areas:", len(info.SwapAreas))
+	for _, a := range info.SwapAreas {
+		fmt.Fprintln(dockerCli.Out(), " "+a.Path)
+		fmt.Fprintln(dockerCli.Out(), "  Type:", a.Type)
+		fmt.Fprintln(dockerCli.Out(), "  Size:", units.BytesSize(float64(a.Size)))
+		fmt.Fprintln(dockerCli.Out(), "  Used:", units.BytesSize(float64(a.Used)))
+		fmt.Fprintln(dockerCli.Out(), "  Priority:", a.Priority)
+		fmt.Fprintln(dockerCli.Out(), "  Containers:", a.Containers)
+	}
+}
+
 // nolint: gocyclo
 func printSwarmInfo(dockerCli command.Cli, info types.Info) {
 	if info.Swarm.LocalNodeState == swarm.LocalNodeStateInactive || info.Swarm.LocalNodeState == swarm.LocalNodeStateLocked {
@@ -44,6 +44,7 @@ var sampleInfoNoSwarm = types.Info{
 	},
 	MemoryLimit:        true,
 	SwapLimit:          true,
+	MemorySwapfile:     true,
 	KernelMemory:       true,
 	CPUCfsPeriod:       true,
 	CPUCfsQuota:        true,
@@ -59,6 +60,7 @@ var sampleInfoNoSwarm = types.Info{
 	SystemTime:         "2017-08-24T17:44:34.077811894Z",
 	LoggingDriver:      "json-file",
 	CgroupDriver:       "cgroupfs",
+	CgroupVersion:      "1",
 	NEventsListener:    0,
 	KernelVersion:      "4.4.0-87-generic",
 	OperatingSystem:    "Ubuntu 16.04.3 LTS",
@@ -84,6 +86,24 @@ var sampleInfoNoSwarm = types.Info{
 		},
 		Mirrors: nil,
 	},
+	SwapAreas: []types.SwapAreaInfo{
+		{
+			Path:       "/dev/sdb2",
+			Type:       "partition",
+			Size:       8589930496,
+			Used:       0,
+			Priority:   -2,
+			Containers: 0,
+		},
+		{
+			Path:       "/mnt/nvme/swapfile",
+			Type:       "file",
+			Size:       4294963200,
+			Used:       104857600,
+			Priority:   10,
+			Containers: 3,
+		},
+	},
 	NCPU:              2,
 	MemTotal:          2097356800,
 	DockerRootDir:     "/var/lib/docker",
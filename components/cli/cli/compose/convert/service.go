@@ -499,6 +499,10 @@ func convertResources(source composetypes.Resources) (*swarm.ResourceRequirement
 			NanoCPUs:    cpus,
 			MemoryBytes: int64(source.Limits.MemoryBytes),
 		}
+		resources.MemorySwap = int64(source.Limits.MemorySwap)
+		resources.MemorySwappiness = source.Limits.MemorySwappiness
+		resources.MemorySwapfile = source.Limits.MemorySwapfile
+		resources.MemorySwapfileSize = int64(source.Limits.MemorySwapfileSize)
 	}
 	if source.Reservations != nil {
 		var cpus int64
@@ -416,6 +416,47 @@ func TestServiceConvertsIsolation(t *testing.T) {
 	assert.Check(t, is.Equal(container.IsolationHyperV, result.TaskTemplate.ContainerSpec.Isolation))
 }
 
+func TestServiceConvertsMemorySwap(t *testing.T) {
+	swappiness := int64(10)
+	src := composetypes.ServiceConfig{
+		Deploy: composetypes.DeployConfig{
+			Resources: composetypes.Resources{
+				Limits: &composetypes.Resource{
+					MemoryBytes:      composetypes.UnitBytes(1024),
+					MemorySwap:       composetypes.UnitBytes(-1),
+					MemorySwappiness: &swappiness,
+					MemorySwapfile:   "/swapfile",
+				},
+			},
+		},
+	}
+	result, err := Service("1.39", Namespace{name: "foo"}, src, nil, nil, nil, nil)
+	assert.NilError(t, err)
+	resources := result.TaskTemplate.Resources
+	assert.Check(t, is.Equal(int64(1024), resources.Limits.MemoryBytes))
+	assert.Check(t, is.Equal(int64(-1), resources.MemorySwap))
+	assert.Check(t, is.DeepEqual(&swappiness, resources.MemorySwappiness))
+	assert.Check(t, is.Equal("/swapfile", resources.MemorySwapfile))
+}
+
+func TestServiceConvertsMemorySwapfileSize(t *testing.T) {
+	src := composetypes.ServiceConfig{
+		Deploy: composetypes.DeployConfig{
+			Resources: composetypes.Resources{
+				Limits: &composetypes.Resource{
+					MemorySwapfile:     "auto",
+					MemorySwapfileSize: composetypes.UnitBytes(1 << 30),
+				},
+			},
+		},
+	}
+	result, err := Service("1.39", Namespace{name: "foo"}, src, nil, nil, nil, nil)
+	assert.NilError(t, err)
+	resources := result.TaskTemplate.Resources
+	assert.Check(t, is.Equal("auto", resources.MemorySwapfile))
+	assert.Check(t, is.Equal(int64(1<<30), resources.MemorySwapfileSize))
+}
+
 func TestConvertServiceSecrets(t *testing.T) {
 	namespace := Namespace{name: "foo"}
 	secrets := []composetypes.ServiceSecretConfig{
@@ -10,7 +10,7 @@ import (
 
 func fullExampleConfig(workingDir, homeDir string) *types.Config {
 	return &types.Config{
-		Version:  "3.7",
+		Version:  "3.8",
 		Services: services(workingDir, homeDir),
 		Networks: networks(),
 		Volumes:  volumes(),
@@ -81,8 +81,12 @@ func services(workingDir, homeDir string) []types.ServiceConfig {
 				},
 				Resources: types.Resources{
 					Limits: &types.Resource{
-						NanoCPUs:    "0.001",
-						MemoryBytes: 50 * 1024 * 1024,
+						NanoCPUs:           "0.001",
+						MemoryBytes:        50 * 1024 * 1024,
+						MemorySwap:         100 * 1024 * 1024,
+						MemorySwappiness:   int64Ptr(10),
+						MemorySwapfile:     "auto",
+						MemorySwapfileSize: 1024 * 1024 * 1024,
 					},
 					Reservations: &types.Resource{
 						NanoCPUs:    "0.0001",
@@ -507,7 +511,7 @@ func secrets(workingDir string) map[string]types.SecretConfig {
 }
 
 func fullExampleYAML(workingDir string) string {
-	return fmt.Sprintf(`version: "3.7"
+	return fmt.Sprintf(`version: "3.8"
 services:
   foo:
     build:
@@ -568,6 +572,10 @@ services:
         limits:
           cpus: "0.001"
           memory: "52428800"
+          memory_swap: "104857600"
+          memory_swappiness: 10
+          memory_swapfile: auto
+          memory_swapfile_size: "1073741824"
         reservations:
           cpus: "0.0001"
           memory: "20971520"
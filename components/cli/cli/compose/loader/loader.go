@@ -849,6 +849,10 @@ func transformSize(value interface{}) (interface{}, error) {
 	case int:
 		return int64(value), nil
 	case string:
+		if value == "-1" {
+			// unlimited, which RAMInBytes does not parse
+			return int64(-1), nil
+		}
 		return units.RAMInBytes(value)
 	}
 	panic(errors.Errorf("invalid type for size %T", value))
@@ -825,6 +825,10 @@ func uint32Ptr(value uint32) *uint32 {
 	return &value
 }
 
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
 func TestFullExample(t *testing.T) {
 	bytes, err := ioutil.ReadFile("full-example.yml")
 	assert.NilError(t, err)
@@ -1459,3 +1463,70 @@ services:
 		})
 	}
 }
+
+func TestLoadMemorySwap(t *testing.T) {
+	config, err := loadYAMLWithEnv(`
+version: '3.8'
+services:
+  foo:
+    image: alpine
+    deploy:
+      resources:
+        limits:
+          memory: 512M
+          memory_swap: -1
+          memory_swappiness: ${SWAPPINESS}
+          memory_swapfile: /swapfile
+  bar:
+    image: alpine
+    deploy:
+      resources:
+        limits:
+          memory: 512M
+          memory_swap: ${SWAP}
+          memory_swapfile: auto
+          memory_swapfile_size: 2G`, map[string]string{"SWAPPINESS": "10", "SWAP": "-1"})
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(config.Services, 2))
+	services := map[string]types.ServiceConfig{}
+	for _, s := range config.Services {
+		services[s.Name] = s
+	}
+	limits := services["foo"].Deploy.Resources.Limits
+	assert.Check(t, is.Equal(types.UnitBytes(512*1024*1024), limits.MemoryBytes))
+	assert.Check(t, is.Equal(types.UnitBytes(-1), limits.MemorySwap))
+	assert.Check(t, is.DeepEqual(int64Ptr(10), limits.MemorySwappiness))
+	assert.Check(t, is.Equal("/swapfile", limits.MemorySwapfile))
+
+	// interpolated values are strings
+	limits = services["bar"].Deploy.Resources.Limits
+	assert.Check(t, is.Equal(types.UnitBytes(-1), limits.MemorySwap))
+	assert.Check(t, is.Equal("auto", limits.MemorySwapfile))
+	assert.Check(t, is.Equal(types.UnitBytes(2*1024*1024*1024), limits.MemorySwapfileSize))
+}
+
+func TestLoadMemorySwapInvalidSwappiness(t *testing.T) {
+	_, err := loadYAML(`
+version: '3.8'
+services:
+  foo:
+    image: alpine
+    deploy:
+      resources:
+        limits:
+          memory_swappiness: 101`)
+	assert.ErrorContains(t, err, "memory_swappiness")
+}
+
+func TestLoadMemorySwapV37(t *testing.T) {
+	_, err := loadYAML(`
+version: '3.7'
+services:
+  foo:
+    image: alpine
+    deploy:
+      resources:
+        limits:
+          memory_swap: 1G`)
+	assert.ErrorContains(t, err, "Additional property memory_swap is not allowed")
+}
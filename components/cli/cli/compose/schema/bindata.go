@@ -508,6 +508,50 @@ nEbFye/DBpf6q/LNQD4WSf11TQ8obKICc9f36nZ7TfvduKfjbxi9rsq/59X/AwAA//+9o87pcUUAAA==
 `,
 	},
 
+	"/data/config_schema_v3.8.json": {
+		local:   "data/config_schema_v3.8.json",
+		size:    18057,
+		modtime: 1518458244,
+		compressed: `
+H4sIAAAAAAAC/+wcyY7juPXuryA4cxvXEmQQJH3LMafknIJboKVnmVMUySEpd7kb/vdA1mIt3GSruqqQ
+amAwbek9km/l29Q/VgjhX3W6h4LgLwjvjZFfHh7+0ILf1U/vhcofMkV25u7x94f62S94vUII06xCSQXf
+0Typ3ySHv97//b5Cr0HMUUIFJLZ/QGrqZwr+LKmCCvkJH0BpKjjerFfVO6mEBGUoaPwF/VghhFAH0j7o
+LauNojzHK4QQOp1XQAhrUAea9lbojvrLw2X9hw5sPV61d1iEEMKSGAOK/2d6NoQQwl+fyN33f9799/Hu
+H/fJ3ea3XwevK/4q2NXbZ7CjnBoqeLc/7iBPzd9O3cYky87AhA323hGmYUgzB/NNqOcQzR3YG9Hc7G+h
+eUjOQbCyCEqwhXojYurtl5GfhlSBCatsDfVmGlttvwzBtdcIEdxCvRHB9fa3EbxqibafEX99uav+fzqv
+6V2vXqV3vjMRA59nY6fN57j52THUwckMJBPH88ntPKsBCuAGd2xCCG9LyrIx1wWHf1dLPPUeIvRj7N5P
+6+H7wS+3UiDkp6X9U+migRdzJsq/dc0CkT6D2lEGsRhE5drDMka1SYRKMpoaKz4jW2A3rZCSdA/JToki
+uMouqSnR1oVaDx5JuSEqh2jO6n2RaPp9wNcnTLmBHBRed7ib0wh3sljYMMc2jRBCm5VlQZwSmZAsGxBB
+lCLH6kTUQKHt9CFccvpnCf9qQIwqYbxupoRcfuFciVImkqjKCv28x6koCsKXMs05dERwfnJJDOy92aP/
+qtut99BJTZgeZHMXAXcTdjgIYS1Klcb6j7l2hBAuaRYPnM8BLkQ2PDcviy0ofJoAn1a+35uV7c1I+oZQ
+DirhpICgHivIgBtKWKIlpAPwVlIeyeAof44V5FQbdbRCXqjoHywDCTzTieDXuV6cQZfOLOomMu67Uupl
+qkulOhseISYaiEr3V+KLglAeI1TgRh2loLUbe3f+Cfgh6fRmNhuAH6gSvGiddNzV3sN/kULD7c6xu2gb
+wtedTW/WQ8idUAWpDtvuvXJcwRbN6zOwT0MVEhOWMMqfl1dxeDGKJHuhzTXRE94DYWaf7iF99qD3oQbY
+QpsYJacFycNAnA7d/1YIBoQPgWQaXEcLRkxTTvEBXh1z4kVF2VtW5HkF6tLfSQ4TGf1nih5AxYaoQl5S
+L9s9HYoNgrlq/w/+el+nqh4bPf+NsWlMbLuCx09GFMZFzQOpFCStgmMFWoc0qkkdkkkEcYGdAOtYv39V
+RjM/k4wSXbDcEKDGdbw5Whaj+hexM0o06NtSw54XOvweqRM23L95cR2ozjXjE8HAUv2AlzHrQTbhEPg1
+81RJM7evOHuIvoFJocxPyawufuoSPtSbn9ZOpMvRw0ivk6F5vFRcftaWLewIstwyqveQzcFRwohUsDjD
+sBai4o3Bk61dFelJRQ+UQQ5ZMIxRQLJEcHaMgNSGqGCNQ0NaKmqOiZBm8RjTXrS6aH1XsxoeaFTuR5+F
+jf+bwoY+6tRcF1trk1GeCAk8aBvaCJnkiqSQSFBUWFkxcLBZqerUYLKMpjknLGRmppC7K0sKxoSNvWS0
+oG6jsWhtRLxWx2r2EM1pXSjOZXsyBH+CEJEZ7ImacXWcDXPnuJ9WkTHQsHF/Xm/dHGRjhZ8Veo2PsXFG
+P3ajKnUwiTvDcJ1EXO2WDvTH8NADGZ3BN1f58WanSN/52l4/OiJAqF8+1lQb4OkxfqMtnbRC5rA/1nwb
+KJK7SzFWvHhbrfX355DCRSqkQzQ3ktFdKa9PRRvDOd4jNPGcnjy2oJwWZYG/oEcH0AzOvHJoP1zMF9C7
+fG9Vqalu9owqny6f/OMaw1EING+eZFSq9Q1B9EGDgyX+gQy/guGMarJlYLeMQXHXgDoQdl2EpsAoCtoa
+u/bADOj32UUxtABRmmvDU6LM/AB3PHaGLrMtbT/Gp0I9yLEGPfW6jXXZJagmMfEI8OzcB4sKXhRIRlOi
+QwHiDUV+JRjbkvQ5aWak5gTlnmhcEkUYA0Z1ETp8IzJGjldpDkII4R2hrFSQkDSiJdLIilMj1PVbFuQl
+abc9gwTsFiGEsFAZuPYEXhYTE24t425HlTZ1GULI5tfQ/Z+cpZ3YbsDl6pAZMfCpEp8q0XNFdW6gl1IH
+axEALTIGKMvYfgUuoBDqOA860d+IdFXoW23eBPAl5ePWSG8+rhd2rs961fz6y+NjYN0544wjNEv100bX
+gq2QIWqlY1X45GrMvmPFyIGDomkysBLHVTyFfaXu0u0WX8dkgtE69V7C7FPB63PEeOQbr4DKH1cJSiGN
+jrpyvlGeiW/zw88FuC0ZSWEUst7KaG0UodzMnuEYs0Uq2IECnoLXLKe1NOSup6HFGhWyKiq9QSvtVuHf
+8NGF1d348pwpwnrllZ5Fam5puaVUZc5Vhwy6nbuhzlW8Jvi1AD83tb6go8YHwsqI3tBV0zSumkoE8sn6
+DVhIpi3YAolrzHRb1HhVA1X1Zxfv74RHqDbh7gKVpFjKw0ZxBF3m39+h7yy33FG+f9++cz2dPHVI9akr
+1K07Xm2iRew0jOXOT/nl/N7iIjGGpPuoOuTMctBPKOtO2hhWl9ZAfXq0GR7to+v/+9PV5vPY4CeYZ6jw
+F603aGhEMv8O5P9BxDq5hK1ibaA+xfpRxDoaRuqJd9oU83E8emJ6hRDqemDdMcZgln/kwpVhOQ/lauGO
+Nm2Y7ad8wXvr/jdPJOv7suGVQsAFxkDtMh2VUFruTr/Rd/uSFn/yxT5CmPDjSEoI/RgO/tRf2w9rySOQ
++qujXqCwiUrMbd/xj8eO2u/pHZOQw+x1Vf13Wv1vAL29gGKJRgAA
+`,
+	},
+
 	"/": {
 		isDir: true,
 		local: "",
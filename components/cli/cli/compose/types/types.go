@@ -240,9 +240,13 @@ type Resources struct {
 // Resource is a resource to be limited or reserved
 type Resource struct {
 	// TODO: types to convert from units and ratios
-	NanoCPUs         string            `mapstructure:"cpus" yaml:"cpus,omitempty"`
-	MemoryBytes      UnitBytes         `mapstructure:"memory" yaml:"memory,omitempty"`
-	GenericResources []GenericResource `mapstructure:"generic_resources" yaml:"generic_resources,omitempty"`
+	NanoCPUs           string            `mapstructure:"cpus" yaml:"cpus,omitempty"`
+	MemoryBytes        UnitBytes         `mapstructure:"memory" yaml:"memory,omitempty"`
+	GenericResources   []GenericResource `mapstructure:"generic_resources" yaml:"generic_resources,omitempty"`
+	MemorySwap         UnitBytes         `mapstructure:"memory_swap" yaml:"memory_swap,omitempty"`
+	MemorySwappiness   *int64            `mapstructure:"memory_swappiness" yaml:"memory_swappiness,omitempty"`
+	MemorySwapfile     string            `mapstructure:"memory_swapfile" yaml:"memory_swapfile,omitempty"`
+	MemorySwapfileSize UnitBytes         `mapstructure:"memory_swapfile_size" yaml:"memory_swapfile_size,omitempty"`
 }
 
 // GenericResource represents a "user defined" resource which can
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/container"
 )
@@ -71,7 +72,9 @@ func ConvertKVStringsToMapWithNil(values []string) map[string]*string {
 	return result
 }
 
-// ParseRestartPolicy returns the parsed policy or an error indicating what is incorrect
+// ParseRestartPolicy returns the parsed policy or an error indicating what is incorrect.
+// The policy is in the "name[:max-retries][,on-unhealthy][,delay=<duration>]
+// [,max-delay=<duration>][,reset-window=<duration>]" form.
 func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
 	p := container.RestartPolicy{}
 
@@ -79,7 +82,33 @@ func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
 		return p, nil
 	}
 
-	parts := strings.Split(policy, ":")
+	fields := strings.Split(policy, ",")
+	for _, field := range fields[1:] {
+		if field == "on-unhealthy" {
+			p.OnUnhealthy = true
+			continue
+		}
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return p, fmt.Errorf("invalid restart policy option %q", field)
+		}
+		d, err := time.ParseDuration(kv[1])
+		if err != nil {
+			return p, fmt.Errorf("invalid restart policy %s: %v", kv[0], err)
+		}
+		switch kv[0] {
+		case "delay":
+			p.InitialDelay = d
+		case "max-delay":
+			p.MaxDelay = d
+		case "reset-window":
+			p.ResetWindow = d
+		default:
+			return p, fmt.Errorf("invalid restart policy option %q", kv[0])
+		}
+	}
+
+	parts := strings.Split(fields[0], ":")
 
 	if len(parts) > 2 {
 		return p, fmt.Errorf("invalid restart policy format")
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -57,6 +58,25 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.ForceRemove = httputils.BoolValue(r, "forcerm")
 	options.MemorySwap = httputils.Int64ValueOrZero(r, "memswap")
 	options.Memory = httputils.Int64ValueOrZero(r, "memory")
+	if r.Form.Get("memswappiness") != "" {
+		swappiness, err := httputils.Int64ValueOrDefault(r, "memswappiness", -1)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		// -1 leaves the swappiness of the build containers unset
+		if swappiness < -1 || swappiness > 100 {
+			return nil, errdefs.InvalidParameter(errors.Errorf("invalid memory swappiness %d: valid range is 0-100", swappiness))
+		}
+		if swappiness != -1 {
+			options.MemorySwappiness = &swappiness
+		}
+	}
+	if swapfile := r.FormValue("memswapfile"); swapfile != "" {
+		if swapfile != "default" && !filepath.IsAbs(swapfile) {
+			return nil, errdefs.InvalidParameter(errors.Errorf("invalid memory swapfile %q: must be an absolute path or \"default\"", swapfile))
+		}
+		options.MemorySwapfile = swapfile
+	}
 	options.CPUShares = httputils.Int64ValueOrZero(r, "cpushares")
 	options.CPUPeriod = httputils.Int64ValueOrZero(r, "cpuperiod")
 	options.CPUQuota = httputils.Int64ValueOrZero(r, "cpuquota")
@@ -0,0 +1,19 @@
+package swap // import "github.com/docker/docker/api/server/router/swap"
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+)
+
+// Backend is the methods that need to be implemented to provide
+// swap area specific functionality
+type Backend interface {
+	List(ctx context.Context, filter filters.Args) ([]*swaptypes.Area, error)
+	Get(ctx context.Context, name string) (*swaptypes.Area, error)
+	Create(ctx context.Context, opts swaptypes.CreateOptions) (*swaptypes.Area, error)
+	Remove(ctx context.Context, name string) error
+	Prune(ctx context.Context, pruneFilters filters.Args) (*types.SwapsPruneReport, error)
+}
@@ -0,0 +1,36 @@
+package swap // import "github.com/docker/docker/api/server/router/swap"
+
+import "github.com/docker/docker/api/server/router"
+
+// swapRouter is a router to talk with the swap areas controller
+type swapRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new swap router
+func NewRouter(b Backend) router.Router {
+	r := &swapRouter{
+		backend: b,
+	}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes to the swap areas controller
+func (r *swapRouter) Routes() []router.Route {
+	return r.routes
+}
+
+func (r *swapRouter) initRoutes() {
+	r.routes = []router.Route{
+		// GET
+		router.NewGetRoute("/swaps", r.getSwapsList),
+		router.NewGetRoute("/swaps/{name:.*}", r.getSwapByName),
+		// POST
+		router.NewPostRoute("/swaps/create", r.postSwapsCreate),
+		router.NewPostRoute("/swaps/prune", r.postSwapsPrune, router.WithCancel),
+		// DELETE
+		router.NewDeleteRoute("/swaps/{name:.*}", r.deleteSwaps),
+	}
+}
@@ -156,11 +156,15 @@ type ImageBuildOptions struct {
 	CPUPeriod      int64
 	Memory         int64
 	MemorySwap     int64
-	CgroupParent   string
-	NetworkMode    string
-	ShmSize        int64
-	Dockerfile     string
-	Ulimits        []*units.Ulimit
+	// MemorySwappiness and MemorySwapfile tune the swap behaviour of the
+	// build containers, like the HostConfig options of the same name.
+	MemorySwappiness *int64
+	MemorySwapfile   string
+	CgroupParent     string
+	NetworkMode      string
+	ShmSize          int64
+	Dockerfile       string
+	Ulimits          []*units.Ulimit
 	// BuildArgs needs to be a *string instead of just a string so that
 	// we can tell the difference between "" (empty string) and no value
 	// at all (nil). See the parsing of buildArgs in
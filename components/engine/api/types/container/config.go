@@ -22,6 +22,9 @@ type HealthConfig struct {
 	// {"NONE"} : disable healthcheck
 	// {"CMD", args...} : exec arguments directly
 	// {"CMD-SHELL", command} : run command with system's default shell
+	// {"HTTP-GET", port[, path]} : GET the path (default "/") on the port of the container
+	// {"TCP", port} : connect to the port of the container
+	// {"GRPC", port[, service]} : call the gRPC health service on the port of the container
 	Test []string `json:",omitempty"`
 
 	// Zero means to inherit. Durations are expressed as integer nanoseconds.
@@ -32,6 +35,14 @@ type HealthConfig struct {
 	// Retries is the number of consecutive failures needed to consider a container as unhealthy.
 	// Zero means inherit.
 	Retries int `json:",omitempty"`
+
+	// StartupTest is the test to perform until it first succeeds, before
+	// Test starts running. It takes the same options as Test.
+	StartupTest []string `json:",omitempty"`
+
+	// StartupInterval is the time to wait between runs of StartupTest.
+	// Zero means inherit.
+	StartupInterval time.Duration `json:",omitempty"`
 }
 
 // Config contains the configuration data about a container.
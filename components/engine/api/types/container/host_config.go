@@ -2,6 +2,7 @@ package container // import "github.com/docker/docker/api/types/container"
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types/blkiodev"
 	"github.com/docker/docker/api/types/mount"
@@ -255,6 +256,10 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              string
 	MaximumRetryCount int
+	InitialDelay      time.Duration `json:",omitempty"` // InitialDelay is the delay before the first restart. Zero means 100ms.
+	MaxDelay          time.Duration `json:",omitempty"` // MaxDelay caps the delay, which doubles on every restart. Zero means 1m.
+	ResetWindow       time.Duration `json:",omitempty"` // ResetWindow is how long the container must run for the delay to be reset. Zero means 10s.
+	OnUnhealthy       bool          `json:",omitempty"` // OnUnhealthy stops the container when it becomes unhealthy, for the policy to restart it.
 }
 
 // IsNone indicates whether the container has the "no" restart policy.
@@ -284,7 +289,70 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount &&
+		rp.InitialDelay == tp.InitialDelay && rp.MaxDelay == tp.MaxDelay &&
+		rp.ResetWindow == tp.ResetWindow && rp.OnUnhealthy == tp.OnUnhealthy
+}
+
+// IdlePolicy represents the policy under which the daemon lowers the memory
+// limit of a container while it is idle, so that its cold pages are swapped
+// out. A container is idle when, over the last Window, its CPU usage stayed
+// below CPUPercent and it sent and received fewer than NetworkBytes. A zero
+// threshold is not taken into account.
+type IdlePolicy struct {
+	Window       time.Duration `json:",omitempty"` // Window is the duration over which the activity of the container is measured.
+	CPUPercent   float64       `json:",omitempty"` // CPUPercent is the CPU usage (in percent of one CPU) below which the container is idle.
+	NetworkBytes int64         `json:",omitempty"` // NetworkBytes is the amount of network traffic below which the container is idle.
+	MemoryLimit  int64         `json:",omitempty"` // MemoryLimit is the memory limit of the container while idle. Zero means its working set.
+}
+
+// MemoryAutoscale represents the policy under which the daemon adjusts the
+// memory limit of a container to its usage, so that its working set stays
+// around TargetPercent of the limit. The limit is kept between Min and Max.
+type MemoryAutoscale struct {
+	Min           int64   `json:",omitempty"` // Min is the lowest memory limit the container can be given.
+	Max           int64   `json:",omitempty"` // Max is the highest memory limit the container can be given.
+	TargetPercent float64 `json:",omitempty"` // TargetPercent is the usage of the memory limit, in percent, the policy aims for.
+}
+
+// Hooks are the commands run at points of the lifecycle of a container.
+// Prestart hooks run after the container is created, before its process
+// starts. Poststart hooks run after its process started, and poststop hooks
+// after the container stopped.
+type Hooks struct {
+	Prestart  []Hook `json:",omitempty"`
+	Poststart []Hook `json:",omitempty"`
+	Poststop  []Hook `json:",omitempty"`
+}
+
+// Hook is a command run at a point of the lifecycle of a container. It runs
+// on the host, where the executable must be allowed by the daemon, or, for
+// poststart hooks, in the container. Prestart and poststop hooks cannot run in
+// the container, which has no running process to run them in.
+type Hook struct {
+	Path        string        // Path is the executable, as an absolute path for hooks run on the host.
+	Args        []string      `json:",omitempty"` // Args are the arguments of the executable, without the executable itself.
+	Env         []string      `json:",omitempty"` // Env is the environment of the executable, in the VAR=value form.
+	Timeout     time.Duration `json:",omitempty"` // Timeout is how long the hook can run for. Zero means no timeout.
+	InContainer bool          `json:",omitempty"` // InContainer runs the hook in the container instead of on the host.
+}
+
+// DependencyCondition is the state a dependency of a container must reach
+// before the container starts.
+type DependencyCondition string
+
+// Available dependency conditions
+const (
+	DependencyStarted   DependencyCondition = "started"
+	DependencyHealthy   DependencyCondition = "healthy"
+	DependencyCompleted DependencyCondition = "completed"
+)
+
+// Dependency represents a container that must be running, and have reached
+// a condition, before a container starts.
+type Dependency struct {
+	Container string              // Container is the name or ID of the dependency.
+	Condition DependencyCondition `json:",omitempty"` // Condition is the state the dependency must reach. Empty means "started".
 }
 
 // LogMode is a type to define the available modes for logging
@@ -332,7 +400,9 @@ type Resources struct {
 	MemoryReservation    int64           // Memory soft limit (in bytes)
 	MemorySwap           int64           // Total memory usage (memory + swap); set `-1` to enable unlimited swap
 	MemorySwappiness     *int64          // Tuning container memory swappiness behaviour
-	MemorySwapfile	   	 *string		 // Tuning container memory swapfile location
+	MemorySwapfile       *string         // Tuning container memory swapfile location; "auto" lets the daemon manage a per-container swapfile
+	MemorySwapfileSize   int64           // Size of the daemon-managed swapfile (in bytes), used when MemorySwapfile is "auto"
+	MemorySwapClass      string          // Swap class (as configured on the daemon) to pick the swap area of the container from
 	OomKillDisable       *bool           // Whether to disable OOM Killer or not
 	PidsLimit            int64           // Setting pids limit for a container
 	Ulimits              []*units.Ulimit // List of ulimits to be set in the container
@@ -366,6 +436,7 @@ type HostConfig struct {
 	AutoRemove      bool          // Automatically remove container when it exits
 	VolumeDriver    string        // Name of the volume driver used to mount volumes
 	VolumesFrom     []string      // List of volumes to take from other container
+	DependsOn       []Dependency  `json:",omitempty"` // Containers to start, and wait for, before this container
 
 	// Applicable to UNIX platforms
 	CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
@@ -391,6 +462,9 @@ type HostConfig struct {
 	ShmSize         int64             // Total shm memory usage
 	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
 	Runtime         string            `json:",omitempty"` // Runtime to use with this container
+	IdlePolicy      *IdlePolicy       `json:",omitempty"` // Policy to lower the memory limit of the container while it is idle
+	MemoryAutoscale *MemoryAutoscale  `json:",omitempty"` // Policy to adjust the memory limit of the container to its usage
+	Hooks           *Hooks            `json:",omitempty"` // Commands to run at points of the lifecycle of the container
 
 	// Applicable to Windows
 	ConsoleSize [2]uint   // Initial console size (height,width)
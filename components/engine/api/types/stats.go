@@ -81,6 +81,52 @@ type MemoryStats struct {
 	PrivateWorkingSet uint64 `json:"privateworkingset,omitempty"`
 }
 
+// SwapStats aggregates the swap usage of a container.
+// Linux only.
+type SwapStats struct {
+	// bytes of the container's memory currently swapped out
+	Usage uint64 `json:"usage,omitempty"`
+	// maximum number of bytes the container can swap out
+	Limit uint64 `json:"limit,omitempty"`
+	// current memory+swap usage
+	MemswUsage uint64 `json:"memsw_usage,omitempty"`
+	// maximum memory+swap usage ever recorded.
+	MemswMaxUsage uint64 `json:"memsw_max_usage,omitempty"`
+	// number of times memory+swap usage hits limits.
+	MemswFailcnt uint64 `json:"memsw_failcnt,omitempty"`
+	// swap area the container is assigned to, if any
+	Swapfile string `json:"swapfile,omitempty"`
+	// total size of the assigned swap area
+	SwapfileSize uint64 `json:"swapfile_size,omitempty"`
+	// bytes in use in the assigned swap area, by all of its users
+	SwapfileUsed uint64 `json:"swapfile_used,omitempty"`
+}
+
+// PressureStats holds the pressure stall information (PSI) of a container.
+// Linux only, with the cgroup v2 unified hierarchy.
+type PressureStats struct {
+	CPU    *Pressure `json:"cpu,omitempty"`
+	Memory *Pressure `json:"memory,omitempty"`
+	IO     *Pressure `json:"io,omitempty"`
+}
+
+// Pressure holds the pressure stall information of a resource.
+type Pressure struct {
+	// share of time some of the tasks were stalled on the resource
+	Some *PressureData `json:"some,omitempty"`
+	// share of time all of the tasks were stalled on the resource
+	Full *PressureData `json:"full,omitempty"`
+}
+
+// PressureData holds the stall ratios, in percent, averaged over 10, 60 and
+// 300 seconds, and the total stall time in microseconds.
+type PressureData struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	Total  uint64  `json:"total"`
+}
+
 // BlkioStatEntry is one small entity to store a piece of Blkio stats
 // Not used on Windows.
 type BlkioStatEntry struct {
@@ -167,6 +213,11 @@ type Stats struct {
 	CPUStats    CPUStats    `json:"cpu_stats,omitempty"`
 	PreCPUStats CPUStats    `json:"precpu_stats,omitempty"` // "Pre"="Previous"
 	MemoryStats MemoryStats `json:"memory_stats,omitempty"`
+
+	// Linux specific stats, not populated on Windows.
+	SwapStats SwapStats `json:"swap_stats,omitempty"`
+	// Linux specific stats, only populated with the cgroup v2 unified hierarchy.
+	PressureStats *PressureStats `json:"pressure_stats,omitempty"`
 }
 
 // StatsJSON is newly used Networks
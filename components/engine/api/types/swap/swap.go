@@ -0,0 +1,45 @@
+package swap // import "github.com/docker/docker/api/types/swap"
+
+// Area describes a swap area known to the daemon.
+type Area struct {
+	// Name of the swap area.
+	Name string
+	// Path of the swap file or block device backing the area.
+	Path string
+	// Type of the area, either "file" or "partition".
+	Type string `json:",omitempty"`
+	// Size of the area in bytes.
+	Size int64
+	// Used is the number of bytes currently swapped out to the area.
+	Used int64
+	// Priority of the area. A negative value leaves the choice to the kernel.
+	Priority int
+	// Active is true if the area is currently in use by the kernel.
+	Active bool
+	// Managed is true if the backing file was created by the daemon, in
+	// which case it is deleted when the area is removed.
+	Managed bool
+	// CreatedAt is the time the area was created, in RFC 3339 format.
+	CreatedAt string `json:",omitempty"`
+	// Labels is the user-defined metadata of the area.
+	Labels map[string]string
+	// Containers holds the IDs of the containers assigned to the area.
+	Containers []string
+}
+
+// CreateOptions holds the parameters to create a swap area.
+type CreateOptions struct {
+	// Name of the swap area. If not specified, the daemon generates a name.
+	Name string
+	// Path of an existing swap file or block device, or of the swap file to
+	// create.
+	Path string
+	// Size of the swap file to create, in bytes. If zero, Path must refer to
+	// an existing swap file or block device.
+	Size int64
+	// Priority of the area. If nil or negative, the choice is left to the
+	// kernel.
+	Priority *int `json:",omitempty"`
+	// Labels is the user-defined metadata of the area.
+	Labels map[string]string
+}
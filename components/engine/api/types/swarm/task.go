@@ -121,6 +121,14 @@ type DiscreteGenericResource struct {
 type ResourceRequirements struct {
 	Limits       *Resources `json:",omitempty"`
 	Reservations *Resources `json:",omitempty"`
+
+	// MemorySwap, MemorySwappiness, MemorySwapfile and MemorySwapfileSize
+	// are applied to the task's container as with `docker run --memory-swap`,
+	// `--memory-swappiness`, `--memory-swapfile` and `--memory-swapfile-size`.
+	MemorySwap         int64  `json:",omitempty"`
+	MemorySwappiness   *int64 `json:",omitempty"`
+	MemorySwapfile     string `json:",omitempty"`
+	MemorySwapfileSize int64  `json:",omitempty"`
 }
 
 // Placement represents orchestration parameters.
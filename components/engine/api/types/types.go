@@ -157,6 +157,7 @@ type Info struct {
 	Plugins            PluginsInfo
 	MemoryLimit        bool
 	SwapLimit          bool
+	MemorySwapfile     bool
 	KernelMemory       bool
 	CPUCfsPeriod       bool `json:"CpuCfsPeriod"`
 	CPUCfsQuota        bool `json:"CpuCfsQuota"`
@@ -172,6 +173,7 @@ type Info struct {
 	SystemTime         string
 	LoggingDriver      string
 	CgroupDriver       string
+	CgroupVersion      string `json:",omitempty"`
 	NEventsListener    int
 	KernelVersion      string
 	OperatingSystem    string
@@ -181,6 +183,7 @@ type Info struct {
 	RegistryConfig     *registry.ServiceConfig
 	NCPU               int
 	MemTotal           int64
+	SwapAreas          []SwapAreaInfo
 	GenericResources   []swarm.GenericResource
 	DockerRootDir      string
 	HTTPProxy          string `json:"HttpProxy"`
@@ -209,6 +212,17 @@ type Info struct {
 	Warnings           []string
 }
 
+// SwapAreaInfo describes an active swap area of the host, as returned by
+// GET "/info"
+type SwapAreaInfo struct {
+	Path       string
+	Type       string
+	Size       int64
+	Used       int64
+	Priority   int
+	Containers int
+}
+
 // KeyValue holds a key/value pair
 type KeyValue struct {
 	Key, Value string
@@ -279,6 +293,9 @@ type HealthcheckResult struct {
 	End      time.Time // End is the time this check ended
 	ExitCode int       // ExitCode meanings: 0=healthy, 1=unhealthy, 2=reserved (considered unhealthy), else=error running probe
 	Output   string    // Output from last check
+
+	StatusCode int           `json:",omitempty"` // StatusCode is the HTTP status code of the response to an HTTP-GET probe
+	Latency    time.Duration `json:",omitempty"` // Latency is the time an HTTP-GET, TCP or GRPC probe took to get its response
 }
 
 // Health states
@@ -302,6 +319,8 @@ type ContainerState struct {
 	Status     string // String representation of the container state. Can be one of "created", "running", "paused", "restarting", "removing", "exited", or "dead"
 	Running    bool
 	Paused     bool
+	Hibernated bool
+	Idle       bool
 	Restarting bool
 	OOMKilled  bool
 	Dead       bool
@@ -311,6 +330,29 @@ type ContainerState struct {
 	StartedAt  string
 	FinishedAt string
 	Health     *Health `json:",omitempty"`
+	// SwapWarning is set when the swap area the container is assigned to
+	// could not be verified or re-applied after a daemon restart.
+	SwapWarning string `json:",omitempty"`
+	// MemoryRecommendation is the memory limit last recommended by the
+	// memory autoscale policy of the container.
+	MemoryRecommendation int64 `json:",omitempty"`
+	// OOMReport is the snapshot taken when the container last ran out of memory.
+	OOMReport *OOMReport `json:",omitempty"`
+}
+
+// OOMReport is a snapshot of the memory usage of a container that ran out of memory.
+type OOMReport struct {
+	Time        time.Time
+	MemoryStats MemoryStats
+	SwapStats   SwapStats
+	Processes   []OOMProcess // Processes are the processes left with the largest RSS, largest first
+}
+
+// OOMProcess is a process of a container listed in an OOMReport.
+type OOMProcess struct {
+	PID     int
+	Command string
+	RSS     uint64 // RSS is the resident set size of the process, in bytes
 }
 
 // ContainerNode stores information about the node that a container
@@ -338,6 +380,7 @@ type ContainerJSONBase struct {
 	HostnamePath    string
 	HostsPath       string
 	LogPath         string
+	SwapArea        string         `json:",omitempty"` // SwapArea is the swap area the container is assigned to, if any
 	Node            *ContainerNode `json:",omitempty"`
 	Name            string
 	RestartCount    int
@@ -533,6 +576,13 @@ type VolumesPruneReport struct {
 	SpaceReclaimed uint64
 }
 
+// SwapsPruneReport contains the response for Engine API:
+// POST "/swaps/prune"
+type SwapsPruneReport struct {
+	SwapsDeleted   []string
+	SpaceReclaimed uint64
+}
+
 // ImagesPruneReport contains the response for Engine API:
 // POST "/images/prune"
 type ImagesPruneReport struct {
@@ -2,6 +2,7 @@ package buildkit
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -313,6 +314,18 @@ func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.
 	}
 	frontendAttrs["add-hosts"] = extraHosts
 
+	memory, err := memoryResources(opt.Options)
+	if err != nil {
+		return nil, err
+	}
+	if memory != nil {
+		dt, err := json.Marshal(memory)
+		if err != nil {
+			return nil, err
+		}
+		frontendAttrs[memoryAttr] = string(dt)
+	}
+
 	exporterAttrs := map[string]string{}
 
 	if len(opt.Options.Tags) > 0 {
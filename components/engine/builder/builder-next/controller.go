@@ -162,7 +162,7 @@ func newController(rt http.RoundTripper, opt Opt) (*control.Controller, error) {
 	wc.Add(w)
 
 	frontends := map[string]frontend.Frontend{
-		"dockerfile.v0": forwarder.NewGatewayForwarder(wc, dockerfile.Build),
+		"dockerfile.v0": forwarder.NewGatewayForwarder(wc, withMemoryResources(dockerfile.Build)),
 		"gateway.v0":    gateway.NewGatewayFrontend(wc),
 	}
 
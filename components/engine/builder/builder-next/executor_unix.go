@@ -3,17 +3,23 @@
 package buildkit
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"sync"
 
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/docker/libnetwork"
+	"github.com/moby/buildkit/cache"
 	"github.com/moby/buildkit/executor"
 	"github.com/moby/buildkit/executor/runcexecutor"
 	"github.com/moby/buildkit/identity"
 	"github.com/moby/buildkit/solver/pb"
 	"github.com/moby/buildkit/util/network"
+	digest "github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
 )
@@ -26,11 +32,116 @@ func newExecutor(root, cgroupParent string, net libnetwork.NetworkController) (e
 		pb.NetMode_HOST:  network.NewHostProvider(),
 		pb.NetMode_NONE:  network.NewNoneProvider(),
 	}
-	return runcexecutor.New(runcexecutor.Opt{
+	opt := runcexecutor.Opt{
 		Root:                filepath.Join(root, "executor"),
 		CommandCandidates:   []string{"runc"},
 		DefaultCgroupParent: cgroupParent,
-	}, networkProviders)
+	}
+	exec, err := runcexecutor.New(opt, networkProviders)
+	if err != nil {
+		return nil, err
+	}
+	return &resourcesExecutor{
+		Executor:         exec,
+		root:             filepath.Join(root, "executor-resources"),
+		opt:              opt,
+		networkProviders: networkProviders,
+		executors:        make(map[string]executor.Executor),
+	}, nil
+}
+
+// resourcesExecutor runs the execs the frontend set memory resources on with
+// an executor applying them to the spec of the exec. The runc executor only
+// lets its network namespaces change the spec, so the executor of a set of
+// resources wraps them.
+type resourcesExecutor struct {
+	executor.Executor
+	root             string
+	opt              runcexecutor.Opt
+	networkProviders map[pb.NetMode]network.Provider
+
+	mu        sync.Mutex
+	executors map[string]executor.Executor // keyed by the digest of the resources
+}
+
+func (e *resourcesExecutor) Exec(ctx context.Context, meta executor.Meta, rootfs cache.Mountable, mounts []executor.Mount, stdin io.ReadCloser, stdout, stderr io.WriteCloser) error {
+	memory, env, err := execMemoryResources(meta.Env)
+	if err != nil {
+		return err
+	}
+	if memory == nil {
+		return e.Executor.Exec(ctx, meta, rootfs, mounts, stdin, stdout, stderr)
+	}
+	meta.Env = env
+	exec, err := e.executor(memory)
+	if err != nil {
+		return err
+	}
+	return exec.Exec(ctx, meta, rootfs, mounts, stdin, stdout, stderr)
+}
+
+// executor returns the executor of a set of memory resources, creating it on
+// first use. Each has its own root, as the runc executor resets the files it
+// shares between its execs when it is created.
+func (e *resourcesExecutor) executor(memory *specs.LinuxMemory) (executor.Executor, error) {
+	dt, err := json.Marshal(memory)
+	if err != nil {
+		return nil, err
+	}
+	key := digest.FromBytes(dt).Hex()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if exec, ok := e.executors[key]; ok {
+		return exec, nil
+	}
+	providers := make(map[pb.NetMode]network.Provider, len(e.networkProviders))
+	for mode, p := range e.networkProviders {
+		providers[mode] = &memoryProvider{Provider: p, memory: memory}
+	}
+	opt := e.opt
+	opt.Root = filepath.Join(e.root, key)
+	exec, err := runcexecutor.New(opt, providers)
+	if err != nil {
+		return nil, err
+	}
+	e.executors[key] = exec
+	return exec, nil
+}
+
+type memoryProvider struct {
+	network.Provider
+	memory *specs.LinuxMemory
+}
+
+func (p *memoryProvider) New() (network.Namespace, error) {
+	ns, err := p.Provider.New()
+	if err != nil {
+		return nil, err
+	}
+	return &memoryNamespace{Namespace: ns, memory: p.memory}, nil
+}
+
+// memoryNamespace applies memory resources to the spec of an exec, along
+// with its network namespace.
+type memoryNamespace struct {
+	network.Namespace
+	memory *specs.LinuxMemory
+}
+
+func (ns *memoryNamespace) Set(s *specs.Spec) {
+	ns.Namespace.Set(s)
+	if s.Linux == nil {
+		s.Linux = &specs.Linux{}
+	}
+	if s.Linux.Resources == nil {
+		s.Linux.Resources = &specs.LinuxResources{}
+	}
+	m := *ns.memory
+	s.Linux.Resources.Memory = &m
+	if cgroup2.IsEnabled() {
+		s.Linux.Resources.Unified = cgroup2.Resources(s.Linux.Resources)
+	}
 }
 
 type bridgeProvider struct {
@@ -0,0 +1,163 @@
+package buildkit
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/swap"
+	gwclient "github.com/moby/buildkit/frontend/gateway/client"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
+)
+
+const minMemory = 4 * 1024 * 1024
+
+// memoryAttr is the frontend attribute the memory resources of the RUN steps
+// of a build are passed to the Dockerfile frontend in, in JSON.
+const memoryAttr = "memory-resources"
+
+// memoryEnv is the environment variable the frontend sets on the exec ops of
+// a build with memory resources, for the executor to apply them. As part of
+// the op, the resources are part of its cache key: a RUN step is not shared
+// with a build with other resources.
+const memoryEnv = "DOCKER_BUILD_MEMORY_RESOURCES"
+
+// memoryResources returns the memory resources of the RUN steps of a build,
+// or nil if the build options do not set any.
+func memoryResources(options *types.ImageBuildOptions) (*specs.LinuxMemory, error) {
+	memory := &specs.LinuxMemory{}
+	set := false
+	if options.Memory != 0 {
+		if options.Memory < minMemory {
+			return nil, errdefs.InvalidParameter(errors.New("minimum memory limit allowed is 4MB"))
+		}
+		memory.Limit = &options.Memory
+		set = true
+	}
+	if options.MemorySwap > 0 {
+		if options.Memory == 0 {
+			return nil, errdefs.InvalidParameter(errors.New("the memory limit must be set when setting the memoryswap limit"))
+		}
+		if options.MemorySwap < options.Memory {
+			return nil, errdefs.InvalidParameter(errors.New("the memoryswap limit must be larger than the memory limit"))
+		}
+		memory.Swap = &options.MemorySwap
+		set = true
+	}
+	if options.MemorySwappiness != nil {
+		swappiness := uint64(*options.MemorySwappiness)
+		memory.Swappiness = &swappiness
+		set = true
+	}
+	if swapfile := options.MemorySwapfile; swapfile != "" {
+		if swapfile != "default" {
+			active, err := swap.IsActive(filepath.Clean(swapfile))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read the active swap areas")
+			}
+			if !active {
+				return nil, errdefs.InvalidParameter(errors.Errorf("invalid swapfile %s: not an active swap area (see /proc/swaps)", swapfile))
+			}
+		}
+		memory.Swapfile = &swapfile
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	return memory, nil
+}
+
+// withMemoryResources wraps a frontend to set the memory resources of the
+// build, if any, on the exec ops it solves.
+func withMemoryResources(f gwclient.BuildFunc) gwclient.BuildFunc {
+	return func(ctx context.Context, c gwclient.Client) (*gwclient.Result, error) {
+		if resources, ok := c.BuildOpts().Opts[memoryAttr]; ok {
+			c = &memoryClient{Client: c, env: memoryEnv + "=" + resources}
+		}
+		return f(ctx, c)
+	}
+}
+
+type memoryClient struct {
+	gwclient.Client
+	env string
+}
+
+func (c *memoryClient) Solve(ctx context.Context, req gwclient.SolveRequest) (*gwclient.Result, error) {
+	if req.Frontend != "" {
+		// the definition is generated by another frontend
+		return nil, errors.New("memory options are not supported with a custom Dockerfile syntax")
+	}
+	if req.Definition != nil {
+		def, err := addExecEnv(req.Definition, c.env)
+		if err != nil {
+			return nil, err
+		}
+		req.Definition = def
+	}
+	return c.Client.Solve(ctx, req)
+}
+
+// addExecEnv returns a copy of an LLB definition with env added to the
+// environment of its exec ops.
+func addExecEnv(def *pb.Definition, env string) (*pb.Definition, error) {
+	res := &pb.Definition{Metadata: make(map[digest.Digest]pb.OpMetadata, len(def.Metadata))}
+	// the ops of a definition follow their inputs, so the digests of the
+	// changed inputs of an op are known when it is reached
+	changed := make(map[digest.Digest]digest.Digest)
+	for _, dt := range def.Def {
+		dgst := digest.FromBytes(dt)
+		var op pb.Op
+		if err := (&op).Unmarshal(dt); err != nil {
+			return nil, errors.Wrap(err, "failed to parse llb proto op")
+		}
+		modified := false
+		for _, inp := range op.Inputs {
+			if d, ok := changed[inp.Digest]; ok {
+				inp.Digest = d
+				modified = true
+			}
+		}
+		if exec := op.GetExec(); exec != nil && exec.Meta != nil {
+			exec.Meta.Env = append(exec.Meta.Env, env)
+			modified = true
+		}
+		newDgst := dgst
+		if modified {
+			var err error
+			if dt, err = op.Marshal(); err != nil {
+				return nil, err
+			}
+			newDgst = digest.FromBytes(dt)
+			changed[dgst] = newDgst
+		}
+		res.Def = append(res.Def, dt)
+		if md, ok := def.Metadata[dgst]; ok {
+			res.Metadata[newDgst] = md
+		}
+	}
+	return res, nil
+}
+
+// execMemoryResources returns the memory resources set by the frontend in
+// the environment of an exec, if any, and the environment without them.
+func execMemoryResources(env []string) (*specs.LinuxMemory, []string, error) {
+	for i, e := range env {
+		if !strings.HasPrefix(e, memoryEnv+"=") {
+			continue
+		}
+		var memory specs.LinuxMemory
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(e, memoryEnv+"=")), &memory); err != nil {
+			return nil, nil, errors.Wrap(err, "invalid memory resources")
+		}
+		return &memory, append(env[:i:i], env[i+1:]...), nil
+	}
+	return nil, env, nil
+}
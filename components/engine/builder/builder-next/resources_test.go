@@ -0,0 +1,84 @@
+package buildkit
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/moby/buildkit/client/llb"
+	"github.com/moby/buildkit/solver/pb"
+	digest "github.com/opencontainers/go-digest"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestMemoryResources(t *testing.T) {
+	memory, err := memoryResources(&types.ImageBuildOptions{})
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(memory))
+
+	swappiness := int64(60)
+	memory, err = memoryResources(&types.ImageBuildOptions{
+		Memory:           64 * 1024 * 1024,
+		MemorySwap:       128 * 1024 * 1024,
+		MemorySwappiness: &swappiness,
+		MemorySwapfile:   "default",
+	})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(int64(64*1024*1024), *memory.Limit))
+	assert.Check(t, is.Equal(int64(128*1024*1024), *memory.Swap))
+	assert.Check(t, is.Equal(uint64(60), *memory.Swappiness))
+	assert.Check(t, is.Equal("default", *memory.Swapfile))
+
+	_, err = memoryResources(&types.ImageBuildOptions{Memory: 1024})
+	assert.Check(t, is.ErrorContains(err, "minimum memory limit"))
+
+	_, err = memoryResources(&types.ImageBuildOptions{Memory: 64 * 1024 * 1024, MemorySwap: 32 * 1024 * 1024})
+	assert.Check(t, is.ErrorContains(err, "must be larger than the memory limit"))
+}
+
+func TestAddExecEnv(t *testing.T) {
+	src := llb.Image("busybox")
+	st := src.Run(llb.Shlex("true")).Root()
+	def, err := st.Marshal()
+	assert.NilError(t, err)
+
+	res, err := addExecEnv(def.ToPB(), "FOO=bar")
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(res.Def, len(def.Def)))
+
+	ops := make(map[digest.Digest]*pb.Op)
+	var last *pb.Op
+	for _, dt := range res.Def {
+		var op pb.Op
+		assert.NilError(t, (&op).Unmarshal(dt))
+		ops[digest.FromBytes(dt)] = &op
+		last = &op
+	}
+	// the inputs and the metadata follow the new digests of the ops
+	assert.Check(t, is.Len(res.Metadata, len(def.Metadata)))
+	for dgst := range res.Metadata {
+		_, ok := ops[dgst]
+		assert.Check(t, ok, "metadata of unknown op %s", dgst)
+	}
+	exec, ok := ops[last.Inputs[0].Digest]
+	assert.Assert(t, ok)
+	assert.Check(t, is.Contains(exec.GetExec().Meta.Env, "FOO=bar"))
+	_, ok = ops[exec.Inputs[0].Digest]
+	assert.Check(t, ok)
+}
+
+func TestExecMemoryResources(t *testing.T) {
+	memory, env, err := execMemoryResources([]string{"PATH=/bin"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(memory))
+	assert.Check(t, is.DeepEqual([]string{"PATH=/bin"}, env))
+
+	memory, env, err = execMemoryResources([]string{"PATH=/bin", memoryEnv + `={"limit":67108864}`, "HOME=/root"})
+	assert.NilError(t, err)
+	assert.Assert(t, memory != nil)
+	assert.Check(t, is.Equal(int64(64*1024*1024), *memory.Limit))
+	assert.Check(t, is.DeepEqual([]string{"PATH=/bin", "HOME=/root"}, env))
+
+	_, _, err = execMemoryResources([]string{memoryEnv + "=64m"})
+	assert.Check(t, is.ErrorContains(err, "invalid memory resources"))
+}
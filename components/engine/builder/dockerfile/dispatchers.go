@@ -13,6 +13,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/docker/docker/api"
@@ -202,7 +203,7 @@ func dispatchTriggeredOnBuild(d dispatchRequest, triggers []string) error {
 		if len(ast.AST.Children) != 1 {
 			return errors.New("onbuild trigger should be a single expression")
 		}
-		cmd, err := instructions.ParseCommand(ast.AST.Children[0])
+		cmd, err := parseCommand(ast.AST.Children[0])
 		if err != nil {
 			if instructions.IsUnknownInstruction(err) {
 				buildsFailed.WithValues(metricsUnknownInstructionError).Inc()
@@ -457,14 +458,62 @@ func dispatchCmd(d dispatchRequest, c *instructions.CmdCommand) error {
 //
 func dispatchHealthcheck(d dispatchRequest, c *instructions.HealthCheckCommand) error {
 	runConfig := d.state.runConfig
-	if runConfig.Healthcheck != nil {
-		oldCmd := runConfig.Healthcheck.Test
+	old := runConfig.Healthcheck
+	if len(c.Health.Test) == 0 && len(c.Health.StartupTest) > 0 {
+		// HEALTHCHECK --startup only sets the startup probe, and keeps the
+		// healthcheck that is already set
+		health := container.HealthConfig{}
+		if old != nil {
+			if len(old.StartupTest) > 0 {
+				fmt.Fprintf(d.builder.Stdout, "Note: overriding previous HEALTHCHECK --startup: %v\n", old.StartupTest)
+			}
+			health = *old
+		}
+		health.StartupTest = c.Health.StartupTest
+		health.StartupInterval = c.Health.StartupInterval
+		runConfig.Healthcheck = &health
+		return d.builder.commit(d.state, healthcheckComment(runConfig.Healthcheck))
+	}
+	if old != nil {
+		oldCmd := old.Test
 		if len(oldCmd) > 0 && oldCmd[0] != "NONE" {
 			fmt.Fprintf(d.builder.Stdout, "Note: overriding previous HEALTHCHECK: %v\n", oldCmd)
 		}
 	}
 	runConfig.Healthcheck = c.Health
-	return d.builder.commit(d.state, fmt.Sprintf("HEALTHCHECK %q", runConfig.Healthcheck))
+	if old != nil && len(old.StartupTest) > 0 && (len(c.Health.Test) == 0 || c.Health.Test[0] != "NONE") {
+		// HEALTHCHECK NONE disables the startup probe as well
+		health := *c.Health
+		health.StartupTest = old.StartupTest
+		health.StartupInterval = old.StartupInterval
+		runConfig.Healthcheck = &health
+	}
+	return d.builder.commit(d.state, healthcheckComment(runConfig.Healthcheck))
+}
+
+// healthcheckComment describes a healthcheck in the comment of the layer
+// that sets it, with the options that are set.
+func healthcheckComment(health *container.HealthConfig) string {
+	parts := []string{"HEALTHCHECK"}
+	duration := func(option string, d time.Duration) {
+		if d != 0 {
+			parts = append(parts, fmt.Sprintf("--%s=%s", option, d))
+		}
+	}
+	duration("interval", health.Interval)
+	duration("timeout", health.Timeout)
+	duration("start-period", health.StartPeriod)
+	if health.Retries != 0 {
+		parts = append(parts, fmt.Sprintf("--retries=%d", health.Retries))
+	}
+	if len(health.StartupTest) > 0 {
+		parts = append(parts, fmt.Sprintf("--startup=%q", health.StartupTest))
+		duration("startup-interval", health.StartupInterval)
+	}
+	if len(health.Test) > 0 {
+		parts = append(parts, fmt.Sprintf("%q", health.Test))
+	}
+	return strings.Join(parts, " ")
 }
 
 // ENTRYPOINT /usr/sbin/nginx
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
@@ -15,6 +17,7 @@ import (
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
 	"github.com/moby/buildkit/frontend/dockerfile/shell"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
@@ -297,6 +300,89 @@ func TestHealthcheckCmd(t *testing.T) {
 	assert.Check(t, is.DeepEqual(expectedTest, sb.state.runConfig.Healthcheck.Test))
 }
 
+func TestHealthcheckProbe(t *testing.T) {
+	testCases := map[string][]string{
+		"HEALTHCHECK HTTP-GET 8080 /healthz":       {"HTTP-GET", "8080", "/healthz"},
+		"HEALTHCHECK --interval=5s TCP 5432":       {"TCP", "5432"},
+		`HEALTHCHECK GRPC ["50051", "my.Service"]`: {"GRPC", "50051", "my.Service"},
+	}
+	for dockerfile, expectedTest := range testCases {
+		result, err := parser.Parse(strings.NewReader(dockerfile))
+		assert.NilError(t, err)
+		cmd, err := parseCommand(result.AST.Children[0])
+		assert.NilError(t, err, dockerfile)
+
+		b := newBuilderWithMockBackend()
+		sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
+		assert.NilError(t, dispatch(sb, cmd))
+		assert.Assert(t, sb.state.runConfig.Healthcheck != nil)
+		assert.Check(t, is.DeepEqual(expectedTest, []string(sb.state.runConfig.Healthcheck.Test)), dockerfile)
+	}
+
+	for dockerfile, expectedErr := range map[string]string{
+		"HEALTHCHECK TCP":               "Usage: HEALTHCHECK TCP <port>",
+		"HEALTHCHECK TCP 5432 5433":     "Usage: HEALTHCHECK TCP <port>",
+		"HEALTHCHECK HTTP-GET 80 / now": "Usage: HEALTHCHECK HTTP-GET <port> [<path>]",
+	} {
+		result, err := parser.Parse(strings.NewReader(dockerfile))
+		assert.NilError(t, err)
+		_, err = parseCommand(result.AST.Children[0])
+		assert.Check(t, is.Error(err, expectedErr), dockerfile)
+	}
+}
+
+func TestHealthcheckStartup(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
+	dispatchLine := func(line string) {
+		result, err := parser.Parse(strings.NewReader(line))
+		assert.NilError(t, err)
+		cmd, err := parseCommand(result.AST.Children[0])
+		assert.NilError(t, err, line)
+		assert.NilError(t, dispatch(sb, cmd), line)
+	}
+
+	// the startup probe and the healthcheck are kept whatever their order
+	dispatchLine("HEALTHCHECK --startup --interval=2s HTTP-GET 8080 /ready")
+	dispatchLine("HEALTHCHECK --interval=30s CMD /bin/check")
+	health := sb.state.runConfig.Healthcheck
+	assert.Assert(t, health != nil)
+	assert.Check(t, is.DeepEqual([]string{"CMD-SHELL", "/bin/check"}, []string(health.Test)))
+	assert.Check(t, is.Equal(30*time.Second, health.Interval))
+	assert.Check(t, is.DeepEqual([]string{"HTTP-GET", "8080", "/ready"}, health.StartupTest))
+	assert.Check(t, is.Equal(2*time.Second, health.StartupInterval))
+
+	dispatchLine("HEALTHCHECK --startup TCP 8080")
+	health = sb.state.runConfig.Healthcheck
+	assert.Check(t, is.DeepEqual([]string{"CMD-SHELL", "/bin/check"}, []string(health.Test)))
+	assert.Check(t, is.DeepEqual([]string{"TCP", "8080"}, health.StartupTest))
+	assert.Check(t, is.Equal(time.Duration(0), health.StartupInterval))
+
+	// HEALTHCHECK NONE disables both
+	dispatchLine("HEALTHCHECK NONE")
+	health = sb.state.runConfig.Healthcheck
+	assert.Check(t, is.DeepEqual([]string{"NONE"}, []string(health.Test)))
+	assert.Check(t, is.Len(health.StartupTest, 0))
+
+	result, err := parser.Parse(strings.NewReader("HEALTHCHECK --startup --retries=3 TCP 8080"))
+	assert.NilError(t, err)
+	_, err = parseCommand(result.AST.Children[0])
+	assert.Check(t, is.Error(err, "HEALTHCHECK --startup only accepts the --interval option"))
+}
+
+func TestHealthcheckComment(t *testing.T) {
+	health := &container.HealthConfig{
+		Test:            []string{"CMD-SHELL", "/bin/check"},
+		Interval:        30 * time.Second,
+		Retries:         3,
+		StartupTest:     []string{"TCP", "8080"},
+		StartupInterval: 2 * time.Second,
+	}
+	expected := `HEALTHCHECK --interval=30s --retries=3 --startup=["TCP" "8080"] --startup-interval=2s ["CMD-SHELL" "/bin/check"]`
+	assert.Check(t, is.Equal(expected, healthcheckComment(health)))
+	assert.Check(t, is.Equal(`HEALTHCHECK ["NONE"]`, healthcheckComment(&container.HealthConfig{Test: []string{"NONE"}})))
+}
+
 func TestEntrypoint(t *testing.T) {
 	b := newBuilderWithMockBackend()
 	sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
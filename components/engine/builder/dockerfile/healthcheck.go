@@ -0,0 +1,217 @@
+package dockerfile // import "github.com/docker/docker/builder/dockerfile"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/strslice"
+	"github.com/moby/buildkit/frontend/dockerfile/command"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/pkg/errors"
+)
+
+// The HEALTHCHECK instruction is parsed by the builder rather than by the
+// instructions package, which knows neither the --startup option nor the
+// probes run by the daemon (HTTP-GET, TCP and GRPC).
+
+// parseDockerfile parses the instructions of a Dockerfile into build stages,
+// as instructions.Parse does.
+func parseDockerfile(ast *parser.Node) ([]instructions.Stage, []instructions.ArgCommand, error) {
+	var (
+		healthchecks []*container.HealthConfig
+		children     []*parser.Node
+	)
+	for _, n := range ast.Children {
+		if n.Value == command.Healthcheck {
+			health, err := parseHealthConfig(n)
+			if err != nil {
+				return nil, nil, errors.Errorf("Dockerfile parse error line %d: %v", n.StartLine, err)
+			}
+			healthchecks = append(healthchecks, health)
+			n = healthcheckPlaceholder(n)
+		}
+		children = append(children, n)
+	}
+
+	root := *ast
+	root.Children = children
+	stages, metaArgs, err := instructions.Parse(&root)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the HEALTHCHECK commands are in the order of the instructions
+	for _, stage := range stages {
+		for _, cmd := range stage.Commands {
+			if c, ok := cmd.(*instructions.HealthCheckCommand); ok {
+				c.Health, healthchecks = healthchecks[0], healthchecks[1:]
+			}
+		}
+	}
+	return stages, metaArgs, nil
+}
+
+// parseCommand parses a single instruction, as instructions.ParseCommand
+// does.
+func parseCommand(node *parser.Node) (instructions.Command, error) {
+	if node.Value != command.Healthcheck {
+		return instructions.ParseCommand(node)
+	}
+	health, err := parseHealthConfig(node)
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := instructions.ParseCommand(healthcheckPlaceholder(node))
+	if err != nil {
+		return nil, err
+	}
+	cmd.(*instructions.HealthCheckCommand).Health = health
+	return cmd, nil
+}
+
+// healthcheckPlaceholder returns a HEALTHCHECK NONE instruction with the
+// position and source of node, for the instructions package to build the
+// command that the parsed healthcheck is then set on.
+func healthcheckPlaceholder(node *parser.Node) *parser.Node {
+	n := *node
+	n.Next = &parser.Node{Value: "NONE"}
+	n.Flags = nil
+	n.Attributes = nil
+	return &n
+}
+
+// parseHealthConfig parses the options and the arguments of a HEALTHCHECK
+// instruction.
+func parseHealthConfig(node *parser.Node) (*container.HealthConfig, error) {
+	var args []string
+	for n := node.Next; n != nil; n = n.Next {
+		args = append(args, n.Value)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("HEALTHCHECK requires at least one argument")
+	}
+
+	typ := strings.ToUpper(args[0])
+	args = args[1:]
+	if typ == "NONE" {
+		if len(args) != 0 {
+			return nil, errors.New("HEALTHCHECK NONE takes no arguments")
+		}
+		return &container.HealthConfig{Test: strslice.StrSlice{typ}}, nil
+	}
+
+	flags := instructions.NewBFlagsWithArgs(node.Flags)
+	flInterval := flags.AddString("interval", "")
+	flTimeout := flags.AddString("timeout", "")
+	flStartPeriod := flags.AddString("start-period", "")
+	flRetries := flags.AddString("retries", "")
+	flStartup := flags.AddBool("startup", false)
+	if err := flags.Parse(); err != nil {
+		return nil, err
+	}
+
+	json := node.Attributes["json"]
+	var test []string
+	switch typ {
+	case "CMD":
+		if !json {
+			typ = "CMD-SHELL"
+			args = []string{strings.Join(args, " ")}
+		}
+		if len(args) == 0 || args[0] == "" {
+			return nil, errors.New("Missing command after HEALTHCHECK CMD")
+		}
+		test = append([]string{typ}, args...)
+	case "HTTP-GET", "TCP", "GRPC":
+		if !json {
+			args = strings.Fields(strings.Join(args, " "))
+		}
+		probe, err := parseProbe(typ, args)
+		if err != nil {
+			return nil, err
+		}
+		test = probe
+	default:
+		return nil, fmt.Errorf("Unknown type %#v in HEALTHCHECK (try CMD, HTTP-GET, TCP or GRPC)", typ)
+	}
+
+	health := &container.HealthConfig{Test: test}
+	var err error
+	if health.Interval, err = parseHealthInterval("interval", flInterval.Value); err != nil {
+		return nil, err
+	}
+	if health.Timeout, err = parseHealthInterval("timeout", flTimeout.Value); err != nil {
+		return nil, err
+	}
+	if health.StartPeriod, err = parseHealthInterval("start-period", flStartPeriod.Value); err != nil {
+		return nil, err
+	}
+	if flRetries.Value != "" {
+		retries, err := strconv.ParseInt(flRetries.Value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if retries < 1 {
+			return nil, fmt.Errorf("--retries must be at least 1 (not %d)", retries)
+		}
+		health.Retries = int(retries)
+	}
+
+	if flStartup.IsTrue() {
+		// the instruction sets the startup probe, which runs until its first
+		// success before the healthcheck starts
+		if flTimeout.Value != "" || flStartPeriod.Value != "" || flRetries.Value != "" {
+			return nil, errors.New("HEALTHCHECK --startup only accepts the --interval option")
+		}
+		health = &container.HealthConfig{
+			StartupTest:     health.Test,
+			StartupInterval: health.Interval,
+		}
+	}
+	return health, nil
+}
+
+// parseProbe returns the test of a probe run by the daemon, from the
+// arguments following its type.
+func parseProbe(typ string, args []string) ([]string, error) {
+	var usage string
+	switch typ {
+	case "HTTP-GET":
+		// HTTP-GET <port> [<path>]
+		if len(args) == 1 || len(args) == 2 {
+			return append([]string{typ}, args...), nil
+		}
+		usage = "HTTP-GET <port> [<path>]"
+	case "TCP":
+		// TCP <port>
+		if len(args) == 1 {
+			return []string{typ, args[0]}, nil
+		}
+		usage = "TCP <port>"
+	case "GRPC":
+		// GRPC <port> [<service>]
+		if len(args) == 1 || len(args) == 2 {
+			return append([]string{typ}, args...), nil
+		}
+		usage = "GRPC <port> [<service>]"
+	}
+	return nil, fmt.Errorf("Usage: HEALTHCHECK %s", usage)
+}
+
+// parseHealthInterval parses the duration set with a HEALTHCHECK option.
+func parseHealthInterval(option, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < container.MinimumDuration {
+		return 0, fmt.Errorf("Interval %#v cannot be less than %s", option, container.MinimumDuration)
+	}
+	return d, nil
+}
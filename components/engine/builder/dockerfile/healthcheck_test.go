@@ -0,0 +1,51 @@
+package dockerfile // import "github.com/docker/docker/builder/dockerfile"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestParseDockerfileHealthcheck(t *testing.T) {
+	dockerfile := `FROM busybox AS build
+HEALTHCHECK --interval=5s TCP 5432
+FROM busybox
+RUN true
+HEALTHCHECK --startup HTTP-GET 8080 /ready
+HEALTHCHECK NONE
+`
+	result, err := parser.Parse(strings.NewReader(dockerfile))
+	assert.NilError(t, err)
+	stages, _, err := parseDockerfile(result.AST)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(stages, 2))
+
+	var healthchecks []*instructions.HealthCheckCommand
+	for _, stage := range stages {
+		for _, cmd := range stage.Commands {
+			if c, ok := cmd.(*instructions.HealthCheckCommand); ok {
+				healthchecks = append(healthchecks, c)
+			}
+		}
+	}
+	assert.Assert(t, is.Len(healthchecks, 3))
+
+	assert.Check(t, is.Equal("HEALTHCHECK --interval=5s TCP 5432", healthchecks[0].String()))
+	assert.Check(t, is.DeepEqual([]string{"TCP", "5432"}, []string(healthchecks[0].Health.Test)))
+	assert.Check(t, is.Equal("5s", healthchecks[0].Health.Interval.String()))
+
+	assert.Check(t, is.Equal("healthcheck", healthchecks[1].Name()))
+	assert.Check(t, is.Len(healthchecks[1].Health.Test, 0))
+	assert.Check(t, is.DeepEqual([]string{"HTTP-GET", "8080", "/ready"}, healthchecks[1].Health.StartupTest))
+
+	assert.Check(t, is.DeepEqual([]string{"NONE"}, []string(healthchecks[2].Health.Test)))
+
+	result, err = parser.Parse(strings.NewReader("FROM busybox\nHEALTHCHECK --startup --timeout=3s TCP 5432\n"))
+	assert.NilError(t, err)
+	_, _, err = parseDockerfile(result.AST)
+	assert.Check(t, is.Error(err, "Dockerfile parse error line 2: HEALTHCHECK --startup only accepts the --interval option"))
+}
@@ -458,6 +458,12 @@ func hostConfigFromOptions(options *types.ImageBuildOptions, isWCOW bool) *conta
 		Memory:       options.Memory,
 		MemorySwap:   options.MemorySwap,
 		Ulimits:      options.Ulimits,
+
+		MemorySwappiness: options.MemorySwappiness,
+	}
+	if options.MemorySwapfile != "" {
+		swapfile := options.MemorySwapfile
+		resources.MemorySwapfile = &swapfile
 	}
 
 	hc := &container.HostConfig{
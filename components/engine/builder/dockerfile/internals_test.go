@@ -171,3 +171,19 @@ func TestDeepCopyRunConfig(t *testing.T) {
 	copy.Shell[0] = "sh"
 	assert.Check(t, is.DeepEqual(fullMutableRunConfig(), runConfig))
 }
+
+func TestHostConfigFromOptionsMemorySwap(t *testing.T) {
+	swappiness := int64(20)
+	options := &types.ImageBuildOptions{
+		Memory:           64 * 1024 * 1024,
+		MemorySwappiness: &swappiness,
+		MemorySwapfile:   "/mnt/ssd/swapfile",
+	}
+	hc := hostConfigFromOptions(options, false)
+	assert.Check(t, is.Equal(swappiness, *hc.MemorySwappiness))
+	assert.Check(t, is.Equal("/mnt/ssd/swapfile", *hc.MemorySwapfile))
+
+	hc = hostConfigFromOptions(&types.ImageBuildOptions{}, false)
+	assert.Check(t, is.Nil(hc.MemorySwappiness))
+	assert.Check(t, is.Nil(hc.MemorySwapfile))
+}
@@ -0,0 +1,13 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// ContainerHibernate freezes a given container and swaps its memory out.
+func (cli *Client) ContainerHibernate(ctx context.Context, containerID string) error {
+	if err := cli.NewVersionError("1.39", "container hibernate"); err != nil {
+		return err
+	}
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/hibernate", nil, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
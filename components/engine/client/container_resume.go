@@ -0,0 +1,14 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// ContainerResume restores the memory limits of a hibernated container and
+// thaws it.
+func (cli *Client) ContainerResume(ctx context.Context, containerID string) error {
+	if err := cli.NewVersionError("1.39", "container resume"); err != nil {
+		return err
+	}
+	resp, err := cli.post(ctx, "/containers/"+containerID+"/resume", nil, nil, nil)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -28,6 +28,7 @@ func TestImageBuildError(t *testing.T) {
 func TestImageBuild(t *testing.T) {
 	v1 := "value1"
 	v2 := "value2"
+	swappiness := int64(10)
 	emptyRegistryConfig := "bnVsbA=="
 	buildCases := []struct {
 		buildOptions           types.ImageBuildOptions
@@ -104,6 +105,21 @@ func TestImageBuild(t *testing.T) {
 			expectedTags:           []string{},
 			expectedRegistryConfig: emptyRegistryConfig,
 		},
+		{
+			buildOptions: types.ImageBuildOptions{
+				Memory:           256,
+				MemorySwappiness: &swappiness,
+				MemorySwapfile:   "/mnt/ssd/swapfile",
+			},
+			expectedQueryParams: map[string]string{
+				"memory":        "256",
+				"memswappiness": "10",
+				"memswapfile":   "/mnt/ssd/swapfile",
+				"rm":            "0",
+			},
+			expectedTags:           []string{},
+			expectedRegistryConfig: emptyRegistryConfig,
+		},
 		{
 			buildOptions: types.ImageBuildOptions{
 				BuildArgs: map[string]*string{
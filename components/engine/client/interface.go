@@ -14,6 +14,7 @@ import (
 	"github.com/docker/docker/api/types/image"
 	networktypes "github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/registry"
+	swaptypes "github.com/docker/docker/api/types/swap"
 	"github.com/docker/docker/api/types/swarm"
 	volumetypes "github.com/docker/docker/api/types/volume"
 )
@@ -30,6 +31,7 @@ type CommonAPIClient interface {
 	ServiceAPIClient
 	SwarmAPIClient
 	SecretAPIClient
+	SwapAPIClient
 	SystemAPIClient
 	VolumeAPIClient
 	ClientVersion() string
@@ -55,6 +57,7 @@ type ContainerAPIClient interface {
 	ContainerExecResize(ctx context.Context, execID string, options types.ResizeOptions) error
 	ContainerExecStart(ctx context.Context, execID string, config types.ExecStartCheck) error
 	ContainerExport(ctx context.Context, container string) (io.ReadCloser, error)
+	ContainerHibernate(ctx context.Context, container string) error
 	ContainerInspect(ctx context.Context, container string) (types.ContainerJSON, error)
 	ContainerInspectWithRaw(ctx context.Context, container string, getSize bool) (types.ContainerJSON, []byte, error)
 	ContainerKill(ctx context.Context, container, signal string) error
@@ -65,6 +68,7 @@ type ContainerAPIClient interface {
 	ContainerRename(ctx context.Context, container, newContainerName string) error
 	ContainerResize(ctx context.Context, container string, options types.ResizeOptions) error
 	ContainerRestart(ctx context.Context, container string, timeout *time.Duration) error
+	ContainerResume(ctx context.Context, container string) error
 	ContainerStatPath(ctx context.Context, container, path string) (types.ContainerPathStat, error)
 	ContainerStats(ctx context.Context, container string, stream bool) (types.ContainerStats, error)
 	ContainerStart(ctx context.Context, container string, options types.ContainerStartOptions) error
@@ -170,6 +174,16 @@ type SystemAPIClient interface {
 	Ping(ctx context.Context) (types.Ping, error)
 }
 
+// SwapAPIClient defines API client methods for the swap areas
+type SwapAPIClient interface {
+	SwapCreate(ctx context.Context, options swaptypes.CreateOptions) (swaptypes.Area, error)
+	SwapInspect(ctx context.Context, name string) (swaptypes.Area, error)
+	SwapInspectWithRaw(ctx context.Context, name string) (swaptypes.Area, []byte, error)
+	SwapList(ctx context.Context, filter filters.Args) ([]swaptypes.Area, error)
+	SwapRemove(ctx context.Context, name string) error
+	SwapsPrune(ctx context.Context, pruneFilter filters.Args) (types.SwapsPruneReport, error)
+}
+
 // VolumeAPIClient defines API client methods for the volumes
 type VolumeAPIClient interface {
 	VolumeCreate(ctx context.Context, options volumetypes.VolumeCreateBody) (types.Volume, error)
@@ -0,0 +1,20 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+
+	swaptypes "github.com/docker/docker/api/types/swap"
+)
+
+// SwapCreate creates a swap area in the docker host.
+func (cli *Client) SwapCreate(ctx context.Context, options swaptypes.CreateOptions) (swaptypes.Area, error) {
+	var area swaptypes.Area
+	resp, err := cli.post(ctx, "/swaps/create", nil, options, nil)
+	if err != nil {
+		return area, err
+	}
+	err = json.NewDecoder(resp.body).Decode(&area)
+	ensureReaderClosed(resp)
+	return area, err
+}
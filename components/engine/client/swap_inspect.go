@@ -0,0 +1,38 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	swaptypes "github.com/docker/docker/api/types/swap"
+)
+
+// SwapInspect returns the information about a specific swap area in the docker host.
+func (cli *Client) SwapInspect(ctx context.Context, name string) (swaptypes.Area, error) {
+	area, _, err := cli.SwapInspectWithRaw(ctx, name)
+	return area, err
+}
+
+// SwapInspectWithRaw returns the information about a specific swap area in the docker host and its raw representation
+func (cli *Client) SwapInspectWithRaw(ctx context.Context, name string) (swaptypes.Area, []byte, error) {
+	if name == "" {
+		return swaptypes.Area{}, nil, objectNotFoundError{object: "swap area", id: name}
+	}
+
+	var area swaptypes.Area
+	resp, err := cli.get(ctx, "/swaps/"+name, nil, nil)
+	if err != nil {
+		return area, nil, wrapResponseError(err, resp, "swap area", name)
+	}
+	defer ensureReaderClosed(resp)
+
+	body, err := ioutil.ReadAll(resp.body)
+	if err != nil {
+		return area, nil, err
+	}
+	rdr := bytes.NewReader(body)
+	err = json.NewDecoder(rdr).Decode(&area)
+	return area, body, err
+}
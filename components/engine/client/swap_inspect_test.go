@@ -0,0 +1,80 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestSwapInspectError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+
+	_, err := client.SwapInspect(context.Background(), "nothing")
+	assert.Check(t, is.ErrorContains(err, "Error response from daemon: Server error"))
+}
+
+func TestSwapInspectNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+
+	_, err := client.SwapInspect(context.Background(), "unknown")
+	assert.Check(t, IsErrNotFound(err))
+}
+
+func TestSwapInspectWithEmptyName(t *testing.T) {
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("should not make request")
+		}),
+	}
+	_, _, err := client.SwapInspectWithRaw(context.Background(), "")
+	assert.Check(t, IsErrNotFound(err))
+}
+
+func TestSwapInspect(t *testing.T) {
+	expectedURL := "/swaps/nvme0"
+	expected := swaptypes.Area{
+		Name:       "nvme0",
+		Path:       "/swap/nvme0",
+		Size:       1 << 30,
+		Priority:   10,
+		Active:     true,
+		Containers: []string{"abc"},
+	}
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "GET" {
+				return nil, fmt.Errorf("expected GET method, got %s", req.Method)
+			}
+			content, err := json.Marshal(expected)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+	}
+
+	area, err := client.SwapInspect(context.Background(), "nvme0")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(expected, area))
+}
@@ -0,0 +1,32 @@
+package client // import "github.com/docker/docker/client"
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+)
+
+// SwapList returns the swap areas known to the docker host.
+func (cli *Client) SwapList(ctx context.Context, filter filters.Args) ([]swaptypes.Area, error) {
+	var areas []swaptypes.Area
+	query := url.Values{}
+
+	if filter.Len() > 0 {
+		filterJSON, err := filters.ToJSON(filter)
+		if err != nil {
+			return areas, err
+		}
+		query.Set("filters", filterJSON)
+	}
+	resp, err := cli.get(ctx, "/swaps", query, nil)
+	if err != nil {
+		return areas, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&areas)
+	ensureReaderClosed(resp)
+	return areas, err
+}
@@ -0,0 +1,10 @@
+package client // import "github.com/docker/docker/client"
+
+import "context"
+
+// SwapRemove removes a swap area from the docker host.
+func (cli *Client) SwapRemove(ctx context.Context, name string) error {
+	resp, err := cli.delete(ctx, "/swaps/"+name, nil, nil)
+	ensureReaderClosed(resp)
+	return wrapResponseError(err, resp, "swap area", name)
+}
@@ -23,6 +23,9 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	// Set default value for `--default-shm-size`
 	conf.ShmSize = opts.MemBytes(config.DefaultShmSize)
 
+	defaultMemorySwappiness := int64(-1)
+	conf.MemorySwappiness = &defaultMemorySwappiness
+
 	// Then platform-specific install flags
 	flags.BoolVar(&conf.EnableSelinuxSupport, "selinux-enabled", false, "Enable selinux support")
 	flags.Var(opts.NewNamedUlimitOpt("default-ulimits", &conf.Ulimits), "default-ulimit", "Default ulimits for containers")
@@ -45,6 +48,10 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.Var(&conf.ShmSize, "default-shm-size", "Default shm size for containers")
 	flags.BoolVar(&conf.NoNewPrivileges, "no-new-privileges", false, "Set no-new-privileges by default for new containers")
 	flags.StringVar(&conf.IpcMode, "default-ipc-mode", config.DefaultIpcMode, `Default mode for containers ipc ("shareable" | "private")`)
+	flags.StringVar(&conf.MemorySwapfile, "default-memory-swapfile", "", "Default swap area for containers")
+	flags.Int64Var(conf.MemorySwappiness, "default-memory-swappiness", defaultMemorySwappiness, "Default memory swappiness for containers (0 to 100)")
+	flags.Float64Var(&conf.MemorySwapRatio, "default-memory-swap-ratio", config.DefaultMemorySwapRatio, "Default ratio of memory+swap to memory for containers with a memory limit (-1 for unlimited swap)")
+	flags.StringVar(&conf.MemorySwapAlert, "memory-swap-alert", "", "Emit swap-high events when a container's swap usage exceeds this percentage of the swap it can use")
 	flags.Var(&conf.NetworkConfig.DefaultAddressPools, "default-address-pool", "Default address pools for node specific local networks")
 
 }
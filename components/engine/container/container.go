@@ -44,6 +44,15 @@ import (
 
 const configFileName = "config.v2.json"
 
+const (
+	// SwapfileDefault is the MemorySwapfile value leaving the choice of swap
+	// area to the kernel.
+	SwapfileDefault = "default"
+	// SwapfileAuto is the MemorySwapfile value requesting a swapfile that is
+	// allocated and managed by the daemon for the lifetime of the container.
+	SwapfileAuto = "auto"
+)
+
 // ExitStatus provides exit reasons for a container.
 type ExitStatus struct {
 	// The exit code with which the container exited.
@@ -102,6 +111,8 @@ type Container struct {
 	ResolvConfPath  string
 	SeccompProfile  string
 	NoNewPrivileges bool
+	SwapfilePath    string // path of the daemon-managed swapfile, if any
+	SwapClassArea   string // swap area picked from the swap class of the container, if any
 
 	// Fields here are specific to Windows
 	NetworkSharedContainerID string   `json:"-"`
@@ -559,6 +570,24 @@ func (container *Container) FullHostname() string {
 	return fullHostname
 }
 
+// SwapArea returns the path of the swap area the container is assigned to,
+// or an empty string if the container uses the default swap areas of the
+// host.
+func (container *Container) SwapArea() string {
+	swapfile := container.HostConfig.MemorySwapfile
+	switch {
+	case swapfile == nil || *swapfile == "":
+		// empty, unless the container has a swap class
+		return container.SwapClassArea
+	case *swapfile == SwapfileDefault:
+		return ""
+	case *swapfile == SwapfileAuto:
+		return container.SwapfilePath
+	default:
+		return filepath.Clean(*swapfile)
+	}
+}
+
 // RestartManager returns the current restartmanager instance connected to container.
 func (container *Container) RestartManager() restartmanager.RestartManager {
 	if container.restartManager == nil {
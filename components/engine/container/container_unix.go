@@ -297,6 +297,9 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.CPUQuota > 0 && cResources.NanoCPUs > 0 {
 		return conflictingUpdateOptions("Conflicting options: CPU Quota cannot be updated as NanoCPUs has already been set")
 	}
+	if resources.MemorySwapClass != "" {
+		return conflictingUpdateOptions("A swap class can only be set when creating the container")
+	}
 
 	if resources.BlkioWeight != 0 {
 		cResources.BlkioWeight = resources.BlkioWeight
@@ -333,6 +336,14 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.MemoryReservation != 0 {
 		cResources.MemoryReservation = resources.MemoryReservation
 	}
+	if resources.MemorySwappiness != nil {
+		swappiness := *resources.MemorySwappiness
+		cResources.MemorySwappiness = &swappiness
+	}
+	if resources.MemorySwapfile != nil {
+		swapfile := *resources.MemorySwapfile
+		cResources.MemorySwapfile = &swapfile
+	}
 	if resources.KernelMemory != 0 {
 		cResources.KernelMemory = resources.KernelMemory
 	}
@@ -10,8 +10,9 @@ import (
 // Health holds the current container health-check state
 type Health struct {
 	types.Health
-	stop chan struct{} // Write struct{} to stop the monitor
-	mu   sync.Mutex
+	stop        chan struct{} // Write struct{} to stop the monitor
+	startupDone bool          // The startup probe has succeeded
+	mu          sync.Mutex
 }
 
 // String returns a human-readable description of the health-check state
@@ -52,6 +53,24 @@ func (s *Health) SetStatus(new string) {
 	s.Health.Status = new
 }
 
+// StartupDone reports whether the startup probe of the container has
+// succeeded since it was started.
+func (s *Health) StartupDone() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.startupDone
+}
+
+// SetStartupDone records whether the startup probe of the container has
+// succeeded since it was started.
+func (s *Health) SetStartupDone(done bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.startupDone = done
+}
+
 // OpenMonitorChannel creates and returns a new monitor channel. If there
 // already is one, it returns nil.
 func (s *Health) OpenMonitorChannel() chan struct{} {
@@ -20,8 +20,12 @@ type State struct {
 	// When pausing a container (on Linux), the cgroups freezer is used to suspend
 	// all processes in the container. Freezing the process requires the process to
 	// be running. As a result, paused containers are both `Running` _and_ `Paused`.
+	// Likewise, hibernated containers are frozen, and are `Running`, `Paused`
+	// _and_ `Hibernated`.
 	Running           bool
 	Paused            bool
+	Hibernated        bool
+	Idle              bool // Idle is set while the memory limit is lowered by the idle policy of the container
 	Restarting        bool
 	OOMKilled         bool
 	RemovalInProgress bool // Not need for this to be persistent on disk.
@@ -29,10 +33,20 @@ type State struct {
 	Pid               int
 	ExitCodeValue     int    `json:"ExitCode"`
 	ErrorMsg          string `json:"Error"` // contains last known error during container start, stop, or remove
+	SwapWarning       string // SwapWarning is set when the swap assignment of the container could not be restored on live-restore
 	StartedAt         time.Time
 	FinishedAt        time.Time
 	Health            *Health
 
+	// MemoryRecommendation is the memory limit last recommended by the
+	// memory autoscale policy of the container.
+	MemoryRecommendation int64
+
+	// OOMReport is the snapshot taken when a process of the container was
+	// last killed because the container ran out of memory. It is kept across
+	// restarts until the next OOM kill.
+	OOMReport *types.OOMReport
+
 	waitStop   chan struct{}
 	waitRemove chan struct{}
 }
@@ -68,6 +82,9 @@ func NewState() *State {
 // String returns a human-readable description of the state
 func (s *State) String() string {
 	if s.Running {
+		if s.Hibernated {
+			return fmt.Sprintf("Up %s (Hibernated)", units.HumanDuration(time.Now().UTC().Sub(s.StartedAt)))
+		}
 		if s.Paused {
 			return fmt.Sprintf("Up %s (Paused)", units.HumanDuration(time.Now().UTC().Sub(s.StartedAt)))
 		}
@@ -267,6 +284,10 @@ func (s *State) SetExitCode(ec int) {
 func (s *State) SetRunning(pid int, initial bool) {
 	s.ErrorMsg = ""
 	s.Paused = false
+	s.Hibernated = false
+	s.Idle = false
+	s.SwapWarning = ""
+	s.MemoryRecommendation = 0
 	s.Running = true
 	s.Restarting = false
 	if initial {
@@ -283,6 +304,10 @@ func (s *State) SetRunning(pid int, initial bool) {
 func (s *State) SetStopped(exitStatus *ExitStatus) {
 	s.Running = false
 	s.Paused = false
+	s.Hibernated = false
+	s.Idle = false
+	s.SwapWarning = ""
+	s.MemoryRecommendation = 0
 	s.Restarting = false
 	s.Pid = 0
 	if exitStatus.ExitedAt.IsZero() {
@@ -304,6 +329,10 @@ func (s *State) SetRestarting(exitStatus *ExitStatus) {
 	s.Running = true
 	s.Restarting = true
 	s.Paused = false
+	s.Hibernated = false
+	s.Idle = false
+	s.SwapWarning = ""
+	s.MemoryRecommendation = 0
 	s.Pid = 0
 	s.FinishedAt = time.Now().UTC()
 	s.ExitCodeValue = exitStatus.ExitCode
@@ -330,6 +359,22 @@ func (s *State) IsPaused() bool {
 	return res
 }
 
+// IsHibernated returns whether the container is hibernated or not.
+func (s *State) IsHibernated() bool {
+	s.Lock()
+	res := s.Hibernated
+	s.Unlock()
+	return res
+}
+
+// IsIdle returns whether the container is idle or not.
+func (s *State) IsIdle() bool {
+	s.Lock()
+	res := s.Idle
+	s.Unlock()
+	return res
+}
+
 // IsRestarting returns whether the container is restarting or not.
 func (s *State) IsRestarting() bool {
 	s.Lock()
@@ -41,10 +41,12 @@ type Snapshot struct {
 	ExitCode     int
 	Running      bool
 	Paused       bool
+	Idle         bool
 	Managed      bool
 	ExposedPorts nat.PortSet
 	PortBindings nat.PortSet
 	Health       string
+	SwapArea     string
 	HostConfig   struct {
 		Isolation string
 	}
@@ -315,6 +317,7 @@ func (v *memdbView) transform(container *Container) *Snapshot {
 		Health:       health,
 		Running:      container.Running,
 		Paused:       container.Paused,
+		Idle:         container.Idle,
 		ExitCode:     container.ExitCode(),
 	}
 
@@ -326,6 +329,7 @@ func (v *memdbView) transform(container *Container) *Snapshot {
 	if container.HostConfig != nil {
 		snapshot.Container.HostConfig.NetworkMode = string(container.HostConfig.NetworkMode)
 		snapshot.HostConfig.Isolation = string(container.HostConfig.Isolation)
+		snapshot.SwapArea = container.SwapArea()
 		for binding := range container.HostConfig.PortBindings {
 			snapshot.PortBindings[binding] = struct{}{}
 		}
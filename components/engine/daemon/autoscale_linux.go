@@ -0,0 +1,174 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// autoscaleInterval is the period over which the peak usage of a
+	// container is measured before its memory limit is lowered.
+	autoscaleInterval = time.Minute
+	// autoscaleHysteresis is the margin, in percent of the memory limit,
+	// around the target of the policy within which the limit is kept.
+	autoscaleHysteresis = 10
+	// autoscaleStep is the granularity of the recommended memory limits.
+	autoscaleStep = 1024 * 1024
+)
+
+// verifyMemoryAutoscale validates the memory autoscale policy of a container,
+// if it has one.
+func verifyMemoryAutoscale(hostConfig *containertypes.HostConfig) error {
+	policy := hostConfig.MemoryAutoscale
+	if policy == nil {
+		return nil
+	}
+	if hostConfig.IdlePolicy != nil {
+		return fmt.Errorf("Conflicting options: idle policy and memory autoscale")
+	}
+	if policy.Min < linuxMinMemory {
+		return fmt.Errorf("Minimum memory autoscale limit allowed is 4MB")
+	}
+	if policy.Max < policy.Min {
+		return fmt.Errorf("Memory autoscale maximum must be greater than or equal to its minimum")
+	}
+	if policy.TargetPercent <= autoscaleHysteresis || policy.TargetPercent > 100-autoscaleHysteresis {
+		return fmt.Errorf("Memory autoscale target must be between %d%% and %d%%", autoscaleHysteresis+1, 100-autoscaleHysteresis)
+	}
+	return nil
+}
+
+// workingSet returns the memory usage of a container, minus the page cache
+// the kernel can reclaim.
+func workingSet(s types.StatsJSON) uint64 {
+	inactive, ok := s.MemoryStats.Stats["total_inactive_file"]
+	if !ok {
+		inactive = s.MemoryStats.Stats["inactive_file"]
+	}
+	if s.MemoryStats.Usage < inactive {
+		return 0
+	}
+	return s.MemoryStats.Usage - inactive
+}
+
+// memoryRecommendation returns the memory limit for which the peak working
+// set of a container is the target of its policy.
+func memoryRecommendation(policy *containertypes.MemoryAutoscale, peak uint64) int64 {
+	limit := int64(float64(peak) * 100 / policy.TargetPercent)
+	limit = (limit + autoscaleStep - 1) / autoscaleStep * autoscaleStep
+	if limit < policy.Min {
+		return policy.Min
+	}
+	if limit > policy.Max {
+		return policy.Max
+	}
+	return limit
+}
+
+// needsScaleUp reports whether the working set of a container exceeds the
+// target of its policy by more than the hysteresis.
+func needsScaleUp(policy *containertypes.MemoryAutoscale, limit int64, usage uint64) bool {
+	return float64(usage)*100 > float64(limit)*(policy.TargetPercent+autoscaleHysteresis)
+}
+
+// needsScaleDown reports whether the peak working set of a container stayed
+// below the target of its policy by more than the hysteresis.
+func needsScaleDown(policy *containertypes.MemoryAutoscale, limit int64, peak uint64) bool {
+	return float64(peak)*100 < float64(limit)*(policy.TargetPercent-autoscaleHysteresis)
+}
+
+// swapForMemory returns the swap limit to set along with a new memory limit,
+// so that the container keeps the swap it is allowed on top of its memory.
+func swapForMemory(resources containertypes.Resources, memory int64) int64 {
+	if resources.MemorySwap <= 0 || resources.Memory <= 0 {
+		return -1
+	}
+	return memory + resources.MemorySwap - resources.Memory
+}
+
+// watchMemoryAutoscale follows the stats of a running container that has a
+// memory autoscale policy, and adjusts its memory limit to its working set.
+// The limit is raised as soon as the working set exceeds the target by more
+// than the hysteresis, and lowered once the peak working set stayed below it
+// for a whole interval. It stops when the container is no longer running.
+func (daemon *Daemon) watchMemoryAutoscale(c *container.Container) {
+	policy := c.HostConfig.MemoryAutoscale
+	if policy == nil {
+		return
+	}
+
+	daemon.watchStats(autoscaleWatcher, c, func() statsHandler {
+		var (
+			peak  uint64
+			since time.Time
+		)
+		return func(s types.StatsJSON) bool {
+			if c.IsPaused() {
+				return true
+			}
+
+			usage := workingSet(s)
+			if since.IsZero() {
+				peak, since = usage, s.Read
+			}
+			if usage > peak {
+				peak = usage
+			}
+
+			c.Lock()
+			limit := c.HostConfig.Memory
+			c.MemoryRecommendation = memoryRecommendation(policy, peak)
+			recommendation := c.MemoryRecommendation
+			c.Unlock()
+
+			switch {
+			case limit == 0 || needsScaleUp(policy, limit, usage):
+			case s.Read.Sub(since) >= autoscaleInterval && needsScaleDown(policy, limit, peak):
+			default:
+				if s.Read.Sub(since) >= autoscaleInterval {
+					// start measuring a new interval
+					peak, since = usage, s.Read
+				}
+				return true
+			}
+			if recommendation != limit {
+				if err := daemon.autoscaleMemory(c, limit, recommendation, peak); err != nil {
+					logrus.WithError(err).WithField("container", c.ID).Warn("failed to adjust the memory limit of container")
+				}
+			}
+			peak, since = usage, s.Read
+			return true
+		}
+	}, nil)
+}
+
+// autoscaleMemory sets the memory limit of a container through the update
+// path, and emits an "autoscale" event recording the adjustment.
+func (daemon *Daemon) autoscaleMemory(c *container.Container, previous, limit int64, usage uint64) error {
+	c.Lock()
+	resources := c.HostConfig.Resources
+	c.Unlock()
+
+	hostConfig := &containertypes.HostConfig{
+		Resources: containertypes.Resources{
+			Memory:     limit,
+			MemorySwap: swapForMemory(resources, limit),
+		},
+	}
+	if _, err := daemon.ContainerUpdate(c.ID, hostConfig); err != nil {
+		return err
+	}
+	daemon.LogContainerEventWithAttributes(c, "autoscale", map[string]string{
+		"memory":         strconv.FormatInt(limit, 10),
+		"previousMemory": strconv.FormatInt(previous, 10),
+		"usage":          strconv.FormatUint(usage, 10),
+	})
+	logrus.WithField("container", c.ID).Debugf("memory limit adjusted from %d to %d", previous, limit)
+	return nil
+}
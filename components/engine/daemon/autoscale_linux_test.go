@@ -0,0 +1,108 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+const mib = 1024 * 1024
+
+func TestVerifyMemoryAutoscale(t *testing.T) {
+	testCases := []struct {
+		doc        string
+		hostConfig containertypes.HostConfig
+		expected   string
+	}{
+		{
+			doc: "no policy",
+		},
+		{
+			doc:        "valid policy",
+			hostConfig: containertypes.HostConfig{MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib, TargetPercent: 80}},
+		},
+		{
+			doc: "idle policy",
+			hostConfig: containertypes.HostConfig{
+				IdlePolicy:      &containertypes.IdlePolicy{},
+				MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib, TargetPercent: 80},
+			},
+			expected: "Conflicting options: idle policy and memory autoscale",
+		},
+		{
+			doc:        "minimum too low",
+			hostConfig: containertypes.HostConfig{MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 1024, Max: 4096 * mib, TargetPercent: 80}},
+			expected:   "Minimum memory autoscale limit allowed is 4MB",
+		},
+		{
+			doc:        "maximum below minimum",
+			hostConfig: containertypes.HostConfig{MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 128 * mib, TargetPercent: 80}},
+			expected:   "Memory autoscale maximum must be greater than or equal to its minimum",
+		},
+		{
+			doc:        "target too high",
+			hostConfig: containertypes.HostConfig{MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib, TargetPercent: 95}},
+			expected:   "Memory autoscale target must be between 11% and 90%",
+		},
+		{
+			doc:        "no target",
+			hostConfig: containertypes.HostConfig{MemoryAutoscale: &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib}},
+			expected:   "Memory autoscale target must be between 11% and 90%",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			err := verifyMemoryAutoscale(&tc.hostConfig)
+			if tc.expected == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Error(t, err, tc.expected)
+		})
+	}
+}
+
+func TestWorkingSet(t *testing.T) {
+	s := types.StatsJSON{}
+	s.MemoryStats.Usage = 100 * mib
+	s.MemoryStats.Stats = map[string]uint64{"total_inactive_file": 30 * mib}
+	assert.Check(t, is.Equal(uint64(70*mib), workingSet(s)))
+
+	// cgroup v2 does not have the hierarchical totals
+	s.MemoryStats.Stats = map[string]uint64{"inactive_file": 40 * mib}
+	assert.Check(t, is.Equal(uint64(60*mib), workingSet(s)))
+
+	s.MemoryStats.Stats = map[string]uint64{"inactive_file": 200 * mib}
+	assert.Check(t, is.Equal(uint64(0), workingSet(s)))
+}
+
+func TestMemoryRecommendation(t *testing.T) {
+	policy := &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib, TargetPercent: 80}
+
+	assert.Check(t, is.Equal(int64(256*mib), memoryRecommendation(policy, 10*mib)))
+	assert.Check(t, is.Equal(int64(1000*mib), memoryRecommendation(policy, 800*mib)))
+	// rounded up to the next MiB
+	assert.Check(t, is.Equal(int64(1001*mib), memoryRecommendation(policy, 800*mib+1)))
+	assert.Check(t, is.Equal(int64(4096*mib), memoryRecommendation(policy, 8192*mib)))
+}
+
+func TestAutoscaleHysteresis(t *testing.T) {
+	policy := &containertypes.MemoryAutoscale{Min: 256 * mib, Max: 4096 * mib, TargetPercent: 80}
+	limit := int64(1000 * mib)
+
+	assert.Check(t, !needsScaleUp(policy, limit, 850*mib))
+	assert.Check(t, needsScaleUp(policy, limit, 950*mib))
+	assert.Check(t, !needsScaleDown(policy, limit, 750*mib))
+	assert.Check(t, needsScaleDown(policy, limit, 650*mib))
+}
+
+func TestSwapForMemory(t *testing.T) {
+	assert.Check(t, is.Equal(int64(-1), swapForMemory(containertypes.Resources{}, 512*mib)))
+	assert.Check(t, is.Equal(int64(-1), swapForMemory(containertypes.Resources{Memory: 256 * mib, MemorySwap: -1}, 512*mib)))
+	// the swap allowance on top of the memory limit is kept
+	assert.Check(t, is.Equal(int64(640*mib), swapForMemory(containertypes.Resources{Memory: 256 * mib, MemorySwap: 384 * mib}, 512*mib)))
+}
@@ -0,0 +1,20 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"errors"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+)
+
+func verifyMemoryAutoscale(hostConfig *containertypes.HostConfig) error {
+	if hostConfig.MemoryAutoscale != nil {
+		return errors.New("memory autoscale is not supported on this platform")
+	}
+	return nil
+}
+
+func (daemon *Daemon) watchMemoryAutoscale(c *container.Container) {
+}
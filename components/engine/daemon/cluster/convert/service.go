@@ -156,13 +156,18 @@ func ServiceSpecToGRPC(s types.ServiceSpec) (swarmapi.ServiceSpec, error) {
 
 	}
 
+	resources, err := resourcesToGRPC(s.TaskTemplate.Resources)
+	if err != nil {
+		return swarmapi.ServiceSpec{}, err
+	}
+
 	spec := swarmapi.ServiceSpec{
 		Annotations: swarmapi.Annotations{
 			Name:   name,
 			Labels: s.Labels,
 		},
 		Task: swarmapi.TaskSpec{
-			Resources:   resourcesToGRPC(s.TaskTemplate.Resources),
+			Resources:   resources,
 			LogDriver:   driverToGRPC(s.TaskTemplate.LogDriver),
 			Networks:    taskNetworks,
 			ForceUpdate: s.TaskTemplate.ForceUpdate,
@@ -358,6 +363,10 @@ func resourcesFromGRPC(res *swarmapi.ResourceRequirements) *types.ResourceRequir
 				GenericResources: GenericResourcesFromGRPC(res.Reservations.Generic),
 			}
 		}
+		resources.MemorySwap = res.MemorySwap
+		resources.MemorySwappiness = swappinessFromGRPC(res.MemorySwappiness)
+		resources.MemorySwapfile = res.MemorySwapfile
+		resources.MemorySwapfileSize = res.MemorySwapfileSize
 	}
 
 	return resources
@@ -381,9 +390,18 @@ func GenericResourcesToGRPC(genericRes []types.GenericResource) []*swarmapi.Gene
 	return generic
 }
 
-func resourcesToGRPC(res *types.ResourceRequirements) *swarmapi.ResourceRequirements {
+func resourcesToGRPC(res *types.ResourceRequirements) (*swarmapi.ResourceRequirements, error) {
 	var reqs *swarmapi.ResourceRequirements
 	if res != nil {
+		if res.MemorySwappiness != nil && (*res.MemorySwappiness < 0 || *res.MemorySwappiness > 100) {
+			return nil, fmt.Errorf("invalid memory swappiness %d: valid range is 0-100", *res.MemorySwappiness)
+		}
+		if res.MemorySwapfile == "auto" && res.MemorySwapfileSize <= 0 {
+			return nil, errors.New("a daemon-managed (auto) swapfile requires a swapfile size")
+		}
+		if res.MemorySwapfile != "auto" && res.MemorySwapfileSize != 0 {
+			return nil, errors.New("a swapfile size can only be set with a daemon-managed (auto) swapfile")
+		}
 		reqs = &swarmapi.ResourceRequirements{}
 		if res.Limits != nil {
 			reqs.Limits = &swarmapi.Resources{
@@ -399,8 +417,27 @@ func resourcesToGRPC(res *types.ResourceRequirements) *swarmapi.ResourceRequirem
 			}
 
 		}
+		reqs.MemorySwap = res.MemorySwap
+		reqs.MemorySwappiness = swappinessToGRPC(res.MemorySwappiness)
+		reqs.MemorySwapfile = res.MemorySwapfile
+		reqs.MemorySwapfileSize = res.MemorySwapfileSize
+	}
+	return reqs, nil
+}
+
+func swappinessFromGRPC(v *gogotypes.Int64Value) *int64 {
+	if v == nil {
+		return nil
+	}
+	value := v.GetValue()
+	return &value
+}
+
+func swappinessToGRPC(v *int64) *gogotypes.Int64Value {
+	if v == nil {
+		return nil
 	}
-	return reqs
+	return &gogotypes.Int64Value{Value: *v}
 }
 
 func restartPolicyFromGRPC(p *swarmapi.RestartPolicy) *types.RestartPolicy {
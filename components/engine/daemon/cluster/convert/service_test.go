@@ -233,6 +233,50 @@ func TestServiceConvertFromGRPCIsolation(t *testing.T) {
 	}
 }
 
+func TestServiceConvertToGRPCMemorySwap(t *testing.T) {
+	swappiness := int64(10)
+	s := swarmtypes.ServiceSpec{
+		TaskTemplate: swarmtypes.TaskSpec{
+			ContainerSpec: &swarmtypes.ContainerSpec{
+				Image: "alpine:latest",
+			},
+			Resources: &swarmtypes.ResourceRequirements{
+				MemorySwap:         1024,
+				MemorySwappiness:   &swappiness,
+				MemorySwapfile:     "auto",
+				MemorySwapfileSize: 64 * 1024 * 1024,
+			},
+		},
+		Mode: swarmtypes.ServiceMode{
+			Global: &swarmtypes.GlobalService{},
+		},
+	}
+	res, err := ServiceSpecToGRPC(s)
+	assert.NilError(t, err)
+	assert.Equal(t, int64(1024), res.Task.Resources.MemorySwap)
+	assert.DeepEqual(t, &google_protobuf3.Int64Value{Value: 10}, res.Task.Resources.MemorySwappiness)
+	assert.Equal(t, "auto", res.Task.Resources.MemorySwapfile)
+	assert.Equal(t, int64(64*1024*1024), res.Task.Resources.MemorySwapfileSize)
+
+	svc, err := ServiceFromGRPC(swarmapi.Service{Spec: res})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, s.TaskTemplate.Resources, svc.Spec.TaskTemplate.Resources)
+
+	swappiness = 101
+	_, err = ServiceSpecToGRPC(s)
+	assert.ErrorContains(t, err, "invalid memory swappiness 101")
+
+	swappiness = 10
+	s.TaskTemplate.Resources.MemorySwapfileSize = 0
+	_, err = ServiceSpecToGRPC(s)
+	assert.ErrorContains(t, err, "requires a swapfile size")
+
+	s.TaskTemplate.Resources.MemorySwapfile = "/swapfile"
+	s.TaskTemplate.Resources.MemorySwapfileSize = 64 * 1024 * 1024
+	_, err = ServiceSpecToGRPC(s)
+	assert.ErrorContains(t, err, "can only be set with a daemon-managed (auto) swapfile")
+}
+
 func TestServiceConvertToGRPCNetworkAtachmentRuntime(t *testing.T) {
 	someid := "asfjkl"
 	s := swarmtypes.ServiceSpec{
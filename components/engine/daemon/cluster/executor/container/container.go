@@ -434,7 +434,22 @@ func (c *containerConfig) resources() enginecontainer.Resources {
 	// TODO(aluzzardi): We might want to set some limits anyway otherwise
 	// "unlimited" tasks will step over the reservation of other tasks.
 	r := c.task.Spec.Resources
-	if r == nil || r.Limits == nil {
+	if r == nil {
+		return resources
+	}
+
+	resources.MemorySwap = r.MemorySwap
+	if r.MemorySwappiness != nil {
+		swappiness := r.MemorySwappiness.GetValue()
+		resources.MemorySwappiness = &swappiness
+	}
+	if r.MemorySwapfile != "" {
+		swapfile := r.MemorySwapfile
+		resources.MemorySwapfile = &swapfile
+		resources.MemorySwapfileSize = r.MemorySwapfileSize
+	}
+
+	if r.Limits == nil {
 		return resources
 	}
 
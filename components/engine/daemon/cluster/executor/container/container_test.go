@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	swarmapi "github.com/docker/swarmkit/api"
+	gogotypes "github.com/gogo/protobuf/types"
 	"gotest.tools/assert"
 )
 
@@ -80,3 +81,41 @@ func TestContainerLabels(t *testing.T) {
 	labels := c.labels()
 	assert.DeepEqual(t, expected, labels)
 }
+
+func TestMemorySwapConversion(t *testing.T) {
+	task := swarmapi.Task{
+		Spec: swarmapi.TaskSpec{
+			Runtime: &swarmapi.TaskSpec_Container{
+				Container: &swarmapi.ContainerSpec{
+					Image: "alpine:latest",
+				},
+			},
+			Resources: &swarmapi.ResourceRequirements{
+				Limits: &swarmapi.Resources{
+					MemoryBytes: 1024 * 1024,
+				},
+				MemorySwap:         -1,
+				MemorySwappiness:   &gogotypes.Int64Value{Value: 0},
+				MemorySwapfile:     "auto",
+				MemorySwapfileSize: 64 * 1024 * 1024,
+			},
+		},
+	}
+	config := containerConfig{task: &task}
+	resources := config.hostConfig().Resources
+	assert.Equal(t, int64(1024*1024), resources.Memory)
+	assert.Equal(t, int64(-1), resources.MemorySwap)
+	assert.Assert(t, resources.MemorySwappiness != nil)
+	assert.Equal(t, int64(0), *resources.MemorySwappiness)
+	assert.Assert(t, resources.MemorySwapfile != nil)
+	assert.Equal(t, "auto", *resources.MemorySwapfile)
+	assert.Equal(t, int64(64*1024*1024), resources.MemorySwapfileSize)
+
+	task.Spec.Resources.MemorySwappiness = nil
+	task.Spec.Resources.MemorySwapfile = ""
+	task.Spec.Resources.MemorySwapfileSize = 0
+	resources = config.hostConfig().Resources
+	assert.Assert(t, resources.MemorySwappiness == nil)
+	assert.Assert(t, resources.MemorySwapfile == nil)
+	assert.Equal(t, int64(0), resources.MemorySwapfileSize)
+}
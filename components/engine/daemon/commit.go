@@ -94,6 +94,12 @@ func merge(userConf, imageConf *containertypes.Config) error {
 			if userConf.Healthcheck.Retries == 0 {
 				userConf.Healthcheck.Retries = imageConf.Healthcheck.Retries
 			}
+			if len(userConf.Healthcheck.StartupTest) == 0 {
+				userConf.Healthcheck.StartupTest = imageConf.Healthcheck.StartupTest
+			}
+			if userConf.Healthcheck.StartupInterval == 0 {
+				userConf.Healthcheck.StartupInterval = imageConf.Healthcheck.StartupInterval
+			}
 		}
 	}
 
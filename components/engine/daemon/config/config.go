@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"os"
 	"reflect"
+	"regexp"
 	"runtime"
 	"strings"
 	"sync"
@@ -56,14 +57,17 @@ var flatOptions = map[string]bool{
 	"default-ulimits":    true,
 	"features":           true,
 	"builder":            true,
+	"swap-classes":       true,
 }
 
 // skipValidateOptions contains configuration keys
 // that will be skipped from findConfigurationConflicts
 // for unknown flag validation.
 var skipValidateOptions = map[string]bool{
-	"features": true,
-	"builder":  true,
+	"features":      true,
+	"builder":       true,
+	"swap-classes":  true,
+	"allowed-hooks": true,
 }
 
 // skipDuplicates contains configuration keys that
@@ -199,6 +203,10 @@ type CommonConfig struct {
 
 	MetricsAddress string `json:"metrics-addr"`
 
+	// MetricsContainerLabels are the container labels added as labels to
+	// the container metrics.
+	MetricsContainerLabels []string `json:"metrics-container-labels,omitempty"`
+
 	LogConfig
 	BridgeConfig // bridgeConfig holds bridge network specific configuration.
 	NetworkConfig
@@ -312,6 +320,14 @@ func ValidateReservedNamespaceLabels(labels []string) error {
 	return nil
 }
 
+var invalidMetricLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// ContainerMetricLabel returns the name of the metric label holding the value
+// of a container label in the container metrics.
+func ContainerMetricLabel(key string) string {
+	return "container_label_" + invalidMetricLabelChars.ReplaceAllString(key, "_")
+}
+
 // Reload reads the configuration in the host and reloads the daemon and server.
 func Reload(configFile string, flags *pflag.FlagSet, reload func(*Config)) error {
 	logrus.Infof("Got signal to reload configuration, reloading from: %s", configFile)
@@ -372,6 +388,12 @@ func MergeDaemonConfigurations(flagsConfig *Config, flags *pflag.FlagSet, config
 	return fileConfig, nil
 }
 
+// zeroValueOptions lists the numeric options for which zero is a meaningful
+// value, that must not be overridden by the default value of the flag.
+var zeroValueOptions = map[string]bool{
+	"default-memory-swappiness": true,
+}
+
 // getConflictFreeConfiguration loads the configuration from a JSON file.
 // It compares that configuration with the one provided by the flags,
 // and returns an error if there are conflicts.
@@ -409,7 +431,7 @@ func getConflictFreeConfiguration(configFile string, flags *pflag.FlagSet) (*Con
 				continue
 			}
 
-			if _, ok := f.Value.(boolValue); ok {
+			if _, ok := f.Value.(boolValue); ok || zeroValueOptions[key] {
 				f.Value.Set(fmt.Sprintf("%v", value))
 			}
 		}
@@ -571,6 +593,16 @@ func Validate(config *Config) error {
 		return err
 	}
 
+	// validate that the container labels map to distinct metric labels
+	metricLabels := make(map[string]string)
+	for _, key := range config.MetricsContainerLabels {
+		name := ContainerMetricLabel(key)
+		if other, ok := metricLabels[name]; ok {
+			return fmt.Errorf("metrics container labels %q and %q both map to the metric label %q", other, key, name)
+		}
+		metricLabels[name] = key
+	}
+
 	if defaultRuntime := config.GetDefaultRuntimeName(); defaultRuntime != "" && defaultRuntime != StockRuntimeName {
 		runtimes := config.GetAllRuntimes()
 		if _, ok := runtimes[defaultRuntime]; !ok {
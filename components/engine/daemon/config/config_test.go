@@ -305,6 +305,13 @@ func TestValidateConfigurationErrors(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerLabels: []string{"com.example.team", "com_example_team"},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -376,6 +383,13 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			config: &Config{
+				CommonConfig: CommonConfig{
+					MetricsContainerLabels: []string{"com.example.team", "com.example.tier"},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		err := Validate(tc.config)
@@ -385,6 +399,11 @@ func TestValidateConfiguration(t *testing.T) {
 	}
 }
 
+func TestContainerMetricLabel(t *testing.T) {
+	assert.Check(t, is.Equal("container_label_com_example_team", ContainerMetricLabel("com.example.team")))
+	assert.Check(t, is.Equal("container_label_app_tier", ContainerMetricLabel("app-tier")))
+}
+
 func TestModifiedDiscoverySettings(t *testing.T) {
 	cases := []struct {
 		current  *Config
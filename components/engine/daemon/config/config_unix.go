@@ -4,6 +4,10 @@ package config // import "github.com/docker/docker/daemon/config"
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/opts"
@@ -13,6 +17,9 @@ import (
 const (
 	// DefaultIpcMode is default for container's IpcMode, if not set otherwise
 	DefaultIpcMode = "shareable" // TODO: change to private
+	// DefaultMemorySwapRatio is the default ratio of a container's
+	// memory+swap limit to its memory limit, if not set otherwise
+	DefaultMemorySwapRatio = 2.0
 )
 
 // Config defines the configuration of a docker daemon.
@@ -37,6 +44,12 @@ type Config struct {
 	ShmSize              opts.MemBytes            `json:"default-shm-size,omitempty"`
 	NoNewPrivileges      bool                     `json:"no-new-privileges,omitempty"`
 	IpcMode              string                   `json:"default-ipc-mode,omitempty"`
+	MemorySwapfile       string                   `json:"default-memory-swapfile,omitempty"`
+	MemorySwappiness     *int64                   `json:"default-memory-swappiness,omitempty"`
+	MemorySwapRatio      float64                  `json:"default-memory-swap-ratio,omitempty"`
+	MemorySwapAlert      string                   `json:"memory-swap-alert,omitempty"`
+	SwapClasses          map[string][]string      `json:"swap-classes,omitempty"`
+	AllowedHooks         []string                 `json:"allowed-hooks,omitempty"`
 	// ResolvConf is the path to the configuration of the host resolver
 	ResolvConf string `json:"resolv-conf,omitempty"`
 }
@@ -83,7 +96,75 @@ func verifyDefaultIpcMode(mode string) error {
 	return nil
 }
 
+func verifyDefaultMemorySwap(conf *Config) error {
+	if conf.MemorySwapfile != "" && conf.MemorySwapfile != "default" && !filepath.IsAbs(conf.MemorySwapfile) {
+		return fmt.Errorf("Default memory swapfile setting (%s) is invalid. Use \"default\" or the absolute path of a swap area.", conf.MemorySwapfile)
+	}
+	if s := conf.MemorySwappiness; s != nil && *s != -1 && (*s < 0 || *s > 100) {
+		return fmt.Errorf("Default memory swappiness setting (%d) is invalid. Valid range is 0-100, or -1 to inherit it from the parent cgroup.", *s)
+	}
+	if conf.MemorySwapRatio != 0 && conf.MemorySwapRatio != -1 && conf.MemorySwapRatio < 1 {
+		return fmt.Errorf("Default memory swap ratio setting (%v) is invalid. Use a ratio of at least 1, or -1 for unlimited swap.", conf.MemorySwapRatio)
+	}
+	return nil
+}
+
+var validSwapClassName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+func verifySwapClasses(classes map[string][]string) error {
+	for name, areas := range classes {
+		if !validSwapClassName.MatchString(name) {
+			return fmt.Errorf("Swap class name (%s) is invalid. Names must start with a letter or a digit, followed by letters, digits, '_', '.' or '-'.", name)
+		}
+		if len(areas) == 0 {
+			return fmt.Errorf("Swap class %s is invalid. It must list at least one swap area.", name)
+		}
+		for _, a := range areas {
+			if !filepath.IsAbs(a) {
+				return fmt.Errorf("Swap class %s is invalid. Swap area %q must be an absolute path.", name, a)
+			}
+		}
+	}
+	return nil
+}
+
+func verifyAllowedHooks(hooks []string) error {
+	for _, h := range hooks {
+		if !filepath.IsAbs(h) {
+			return fmt.Errorf("Allowed hook %q is invalid. It must be an absolute path.", h)
+		}
+	}
+	return nil
+}
+
+// MemorySwapAlertThreshold returns the swap usage of a container, as a
+// percentage of the swap it can use, from which swap pressure events are
+// emitted. It returns 0 if swap pressure events are disabled.
+func (conf *Config) MemorySwapAlertThreshold() (float64, error) {
+	if conf.MemorySwapAlert == "" {
+		return 0, nil
+	}
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(conf.MemorySwapAlert, "%"), 64)
+	if err != nil || threshold <= 0 || threshold > 100 {
+		return 0, fmt.Errorf("Memory swap alert setting (%s) is invalid. Use a percentage between 0 and 100, for example \"80%%\".", conf.MemorySwapAlert)
+	}
+	return threshold, nil
+}
+
 // ValidatePlatformConfig checks if any platform-specific configuration settings are invalid.
 func (conf *Config) ValidatePlatformConfig() error {
-	return verifyDefaultIpcMode(conf.IpcMode)
+	if err := verifyDefaultIpcMode(conf.IpcMode); err != nil {
+		return err
+	}
+	if err := verifyDefaultMemorySwap(conf); err != nil {
+		return err
+	}
+	if err := verifySwapClasses(conf.SwapClasses); err != nil {
+		return err
+	}
+	if err := verifyAllowedHooks(conf.AllowedHooks); err != nil {
+		return err
+	}
+	_, err := conf.MemorySwapAlertThreshold()
+	return err
 }
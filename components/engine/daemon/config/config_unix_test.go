@@ -132,3 +132,151 @@ func TestDaemonConfigurationMergeShmSize(t *testing.T) {
 	expectedValue := 1 * 1024 * 1024 * 1024
 	assert.Check(t, is.Equal(int64(expectedValue), cc.ShmSize.Value()))
 }
+
+func TestDaemonConfigurationMergeMemorySwap(t *testing.T) {
+	data := `{"default-memory-swapfile": "/swap/nvme0", "default-memory-swappiness": 0, "default-memory-swap-ratio": 1.5}`
+
+	file := fs.NewFile(t, "docker-config", fs.WithContent(data))
+	defer file.Remove()
+
+	swappiness := int64(-1)
+	c := &Config{MemorySwappiness: &swappiness}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVar(&c.MemorySwapfile, "default-memory-swapfile", "", "")
+	flags.Int64Var(c.MemorySwappiness, "default-memory-swappiness", -1, "")
+	flags.Float64Var(&c.MemorySwapRatio, "default-memory-swap-ratio", DefaultMemorySwapRatio, "")
+
+	cc, err := MergeDaemonConfigurations(c, flags, file.Path())
+	assert.NilError(t, err)
+
+	assert.Check(t, is.Equal("/swap/nvme0", cc.MemorySwapfile))
+	assert.Assert(t, cc.MemorySwappiness != nil)
+	assert.Check(t, is.Equal(int64(0), *cc.MemorySwappiness))
+	assert.Check(t, is.Equal(1.5, cc.MemorySwapRatio))
+}
+
+func TestValidatePlatformConfigMemorySwap(t *testing.T) {
+	swappiness := int64(101)
+	testCases := []struct {
+		config      *Config
+		expectedErr string
+	}{
+		{config: &Config{MemorySwapfile: "default", MemorySwapRatio: 2}},
+		{config: &Config{MemorySwapfile: "/swap/nvme0", MemorySwapRatio: -1}},
+		{
+			config:      &Config{MemorySwapfile: "swap/nvme0"},
+			expectedErr: "Default memory swapfile setting (swap/nvme0) is invalid",
+		},
+		{
+			config:      &Config{MemorySwappiness: &swappiness},
+			expectedErr: "Default memory swappiness setting (101) is invalid",
+		},
+		{
+			config:      &Config{MemorySwapRatio: 0.5},
+			expectedErr: "Default memory swap ratio setting (0.5) is invalid",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.config.ValidatePlatformConfig()
+		if tc.expectedErr == "" {
+			assert.Check(t, err)
+		} else {
+			assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+		}
+	}
+}
+
+func TestMemorySwapAlertThreshold(t *testing.T) {
+	testCases := []struct {
+		value       string
+		expected    float64
+		expectedErr string
+	}{
+		{value: "", expected: 0},
+		{value: "80%", expected: 80},
+		{value: "92.5", expected: 92.5},
+		{value: "100%", expected: 100},
+		{value: "0%", expectedErr: "Memory swap alert setting (0%) is invalid"},
+		{value: "120%", expectedErr: "Memory swap alert setting (120%) is invalid"},
+		{value: "high", expectedErr: "Memory swap alert setting (high) is invalid"},
+	}
+	for _, tc := range testCases {
+		conf := &Config{MemorySwapAlert: tc.value}
+		threshold, err := conf.MemorySwapAlertThreshold()
+		if tc.expectedErr == "" {
+			assert.Check(t, err)
+			assert.Check(t, is.Equal(tc.expected, threshold))
+		} else {
+			assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+			assert.Check(t, is.ErrorContains(conf.ValidatePlatformConfig(), tc.expectedErr))
+		}
+	}
+}
+
+func TestSwapClasses(t *testing.T) {
+	configFileData := `
+		{
+			"swap-classes": {
+				"fast": ["/swap/nvme0", "/swap/nvme1"],
+				"bulk": ["/dev/sdb2"]
+			}
+		}`
+
+	file := fs.NewFile(t, "docker-config", fs.WithContent(configFileData))
+	defer file.Remove()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cc, err := getConflictFreeConfiguration(file.Path(), flags)
+	assert.NilError(t, err)
+	assert.Check(t, cc.IsValueSet("swap-classes"))
+	assert.Check(t, is.DeepEqual(map[string][]string{
+		"fast": {"/swap/nvme0", "/swap/nvme1"},
+		"bulk": {"/dev/sdb2"},
+	}, cc.SwapClasses))
+	assert.Check(t, cc.ValidatePlatformConfig())
+}
+
+func TestSwapClassesInvalid(t *testing.T) {
+	testCases := []struct {
+		classes     map[string][]string
+		expectedErr string
+	}{
+		{
+			classes:     map[string][]string{"-fast": {"/swap/nvme0"}},
+			expectedErr: "Swap class name (-fast) is invalid",
+		},
+		{
+			classes:     map[string][]string{"fast": {}},
+			expectedErr: "Swap class fast is invalid. It must list at least one swap area.",
+		},
+		{
+			classes:     map[string][]string{"fast": {"nvme0"}},
+			expectedErr: `Swap class fast is invalid. Swap area "nvme0" must be an absolute path.`,
+		},
+	}
+	for _, tc := range testCases {
+		conf := &Config{SwapClasses: tc.classes}
+		assert.Check(t, is.ErrorContains(conf.ValidatePlatformConfig(), tc.expectedErr))
+	}
+}
+
+func TestAllowedHooks(t *testing.T) {
+	configFileData := `
+		{
+			"allowed-hooks": ["/usr/local/bin/register-service", "/usr/local/bin/flush-cache"]
+		}`
+
+	file := fs.NewFile(t, "docker-config", fs.WithContent(configFileData))
+	defer file.Remove()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cc, err := getConflictFreeConfiguration(file.Path(), flags)
+	assert.NilError(t, err)
+	assert.Check(t, cc.IsValueSet("allowed-hooks"))
+	assert.Check(t, is.DeepEqual([]string{"/usr/local/bin/register-service", "/usr/local/bin/flush-cache"}, cc.AllowedHooks))
+	assert.Check(t, cc.ValidatePlatformConfig())
+
+	conf := &Config{AllowedHooks: []string{"register-service"}}
+	assert.Check(t, is.Error(conf.ValidatePlatformConfig(), `Allowed hook "register-service" is invalid. It must be an absolute path.`))
+}
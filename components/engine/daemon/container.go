@@ -284,6 +284,18 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 			if config.Healthcheck.StartPeriod != 0 && config.Healthcheck.StartPeriod < containertypes.MinimumDuration {
 				return nil, errors.Errorf("StartPeriod in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 			}
+
+			if err := validateProbeTest(config.Healthcheck.Test); err != nil {
+				return nil, err
+			}
+
+			if config.Healthcheck.StartupInterval != 0 && config.Healthcheck.StartupInterval < containertypes.MinimumDuration {
+				return nil, errors.Errorf("StartupInterval in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
+			}
+
+			if err := validateProbeTest(config.Healthcheck.StartupTest); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -322,6 +334,10 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 		}
 	}
 
+	if err := daemon.verifyDependencies(hostConfig, update); err != nil {
+		return nil, err
+	}
+
 	p := hostConfig.RestartPolicy
 
 	switch p.Name {
@@ -339,6 +355,16 @@ func (daemon *Daemon) verifyContainerSettings(platform string, hostConfig *conta
 		return nil, errors.Errorf("invalid restart policy '%s'", p.Name)
 	}
 
+	if p.InitialDelay < 0 || p.MaxDelay < 0 || p.ResetWindow < 0 {
+		return nil, errors.Errorf("restart delays cannot be negative")
+	}
+	if p.MaxDelay != 0 && p.InitialDelay > p.MaxDelay {
+		return nil, errors.Errorf("restart initial delay cannot be greater than the maximum delay")
+	}
+	if p.IsNone() && (p.InitialDelay != 0 || p.MaxDelay != 0 || p.ResetWindow != 0 || p.OnUnhealthy) {
+		return nil, errors.Errorf("restart delays and on-unhealthy cannot be used without a restart policy")
+	}
+
 	if !hostConfig.Isolation.IsValid() {
 		return nil, errors.Errorf("invalid isolation '%s' on %s", hostConfig.Isolation, runtime.GOOS)
 	}
@@ -0,0 +1,173 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// containerMetricsEntry holds the last stats published for a container.
+type containerMetricsEntry struct {
+	labels []string
+	stats  *types.StatsJSON
+}
+
+// containerMetrics exports the resource usage of the running containers, as
+// last published by the stats collector. The metrics are labelled by
+// container ID and name, and by the selected container labels.
+type containerMetrics struct {
+	mu        sync.Mutex
+	labelKeys []string
+	entries   map[string]*containerMetricsEntry
+
+	memoryUsage *prometheus.Desc
+	memoryLimit *prometheus.Desc
+	swapUsage   *prometheus.Desc
+	swapLimit   *prometheus.Desc
+	swapfile    *prometheus.Desc
+	cpuUsage    *prometheus.Desc
+	blkio       *prometheus.Desc
+	pids        *prometheus.Desc
+	pidsLimit   *prometheus.Desc
+}
+
+func newContainerMetrics(ns *metrics.Namespace, labelKeys []string) *containerMetrics {
+	labels := []string{"id", "name"}
+	for _, k := range labelKeys {
+		labels = append(labels, config.ContainerMetricLabel(k))
+	}
+	with := func(extra ...string) []string {
+		return append(append([]string{}, labels...), extra...)
+	}
+	return &containerMetrics{
+		labelKeys:   labelKeys,
+		entries:     make(map[string]*containerMetricsEntry),
+		memoryUsage: ns.NewDesc("container_memory_usage", "The memory usage of the container", metrics.Bytes, labels...),
+		memoryLimit: ns.NewDesc("container_memory_limit", "The memory limit of the container", metrics.Bytes, labels...),
+		swapUsage:   ns.NewDesc("container_swap_usage", "The swap usage of the container", metrics.Bytes, labels...),
+		swapLimit:   ns.NewDesc("container_swap_limit", "The swap limit of the container", metrics.Bytes, labels...),
+		swapfile:    ns.NewDesc("container_swapfile", "The swap area the container is assigned to", metrics.Unit("info"), with("swapfile")...),
+		cpuUsage:    ns.NewDesc("container_cpu_usage", "The CPU time consumed by the container", metrics.Seconds, labels...),
+		blkio:       ns.NewDesc("container_blkio", "The number of bytes read from and written to block devices by the container", metrics.Bytes, with("op")...),
+		pids:        ns.NewDesc("container_pids", "The number of processes in the container", "", labels...),
+		pidsLimit:   ns.NewDesc("container_pids_limit", "The process limit of the container", "", labels...),
+	}
+}
+
+// add starts tracking a container, replacing the entry of its previous
+// start, if any.
+func (m *containerMetrics) add(c *container.Container) *containerMetricsEntry {
+	labels := []string{c.ID, strings.TrimPrefix(c.Name, "/")}
+	for _, k := range m.labelKeys {
+		labels = append(labels, c.Config.Labels[k])
+	}
+	e := &containerMetricsEntry{labels: labels}
+	m.mu.Lock()
+	m.entries[c.ID] = e
+	m.mu.Unlock()
+	return e
+}
+
+func (m *containerMetrics) update(e *containerMetricsEntry, s types.StatsJSON) {
+	m.mu.Lock()
+	e.stats = &s
+	m.mu.Unlock()
+}
+
+// del stops tracking a container, unless its entry was replaced already.
+func (m *containerMetrics) del(id string, e *containerMetricsEntry) {
+	m.mu.Lock()
+	if m.entries[id] == e {
+		delete(m.entries, id)
+	}
+	m.mu.Unlock()
+}
+
+func (m *containerMetrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		m.memoryUsage, m.memoryLimit, m.swapUsage, m.swapLimit, m.swapfile,
+		m.cpuUsage, m.blkio, m.pids, m.pidsLimit,
+	} {
+		ch <- d
+	}
+}
+
+func (m *containerMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.entries {
+		s := e.stats
+		if s == nil {
+			continue
+		}
+		metric := func(desc *prometheus.Desc, valueType prometheus.ValueType, v float64, extra ...string) {
+			cm, err := prometheus.NewConstMetric(desc, valueType, v, append(append([]string{}, e.labels...), extra...)...)
+			if err != nil {
+				cm = prometheus.NewInvalidMetric(desc, err)
+			}
+			ch <- cm
+		}
+		gauge := func(desc *prometheus.Desc, v uint64, extra ...string) {
+			metric(desc, prometheus.GaugeValue, float64(v), extra...)
+		}
+		gauge(m.memoryUsage, s.MemoryStats.Usage)
+		gauge(m.memoryLimit, s.MemoryStats.Limit)
+		gauge(m.swapUsage, s.SwapStats.Usage)
+		gauge(m.swapLimit, s.SwapStats.Limit)
+		if s.SwapStats.Swapfile != "" {
+			gauge(m.swapfile, 1, s.SwapStats.Swapfile)
+		}
+		metric(m.cpuUsage, prometheus.CounterValue, float64(s.CPUStats.CPUUsage.TotalUsage)/1e9)
+		var read, write uint64
+		for _, b := range s.BlkioStats.IoServiceBytesRecursive {
+			switch strings.ToLower(b.Op) {
+			case "read":
+				read += b.Value
+			case "write":
+				write += b.Value
+			}
+		}
+		metric(m.blkio, prometheus.CounterValue, float64(read), "read")
+		metric(m.blkio, prometheus.CounterValue, float64(write), "write")
+		gauge(m.pids, s.PidsStats.Current)
+		if s.PidsStats.Limit > 0 {
+			gauge(m.pidsLimit, s.PidsStats.Limit)
+		}
+	}
+}
+
+// registerContainerMetrics exports the resource usage of the containers and,
+// where supported, of the swap areas of the host, labelled by the given
+// container labels.
+func (daemon *Daemon) registerContainerMetrics(labelKeys []string) {
+	ns := metrics.NewNamespace("engine", "daemon", nil)
+	daemon.containerMetrics = newContainerMetrics(ns, labelKeys)
+	ns.Add(daemon.containerMetrics)
+	daemon.addPlatformMetrics(ns)
+	metrics.Register(ns)
+}
+
+// watchContainerMetrics follows the stats of a running container to export
+// them as metrics, until it is no longer running. It does nothing if the
+// metrics are not exported.
+func (daemon *Daemon) watchContainerMetrics(c *container.Container) {
+	m := daemon.containerMetrics
+	if m == nil {
+		return
+	}
+
+	e := m.add(c)
+	daemon.watchStats(metricsWatcher, c, func() statsHandler {
+		return func(s types.StatsJSON) bool {
+			m.update(e, s)
+			return true
+		}
+	}, func() {
+		m.del(c.ID, e)
+	})
+}
@@ -0,0 +1,91 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestContainerMetricsCollect(t *testing.T) {
+	ns := metrics.NewNamespace("engine", "daemon", nil)
+	m := newContainerMetrics(ns, []string{"com.example.team"})
+	c := &container.Container{
+		ID:     "abcdef",
+		Name:   "/web",
+		Config: &containertypes.Config{Labels: map[string]string{"com.example.team": "payments"}},
+	}
+	previous := m.add(c)
+	e := m.add(c)
+
+	// no stats have been published yet
+	assert.Check(t, is.Len(collectMetrics(m), 0))
+
+	s := types.StatsJSON{}
+	s.MemoryStats.Usage = 100
+	s.MemoryStats.Limit = 200
+	s.SwapStats = types.SwapStats{Usage: 10, Limit: 50, Swapfile: "/swap/web"}
+	s.CPUStats.CPUUsage.TotalUsage = 1500000000
+	s.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 3},
+		{Major: 8, Minor: 0, Op: "Write", Value: 4},
+		{Major: 8, Minor: 0, Op: "Total", Value: 7},
+		{Major: 8, Minor: 16, Op: "read", Value: 5},
+	}
+	s.PidsStats.Current = 4
+	m.update(e, s)
+
+	collected := collectMetrics(m)
+	// memory usage and limit, swap usage and limit, swapfile, cpu, blkio
+	// read and write, and pids
+	assert.Check(t, is.Len(collected, 9))
+	for _, metric := range collected {
+		var pb dto.Metric
+		assert.NilError(t, metric.Write(&pb))
+		labels := make(map[string]string)
+		for _, l := range pb.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		assert.Check(t, is.Equal("abcdef", labels["id"]))
+		assert.Check(t, is.Equal("web", labels["name"]))
+		assert.Check(t, is.Equal("payments", labels["container_label_com_example_team"]))
+
+		switch metric.Desc() {
+		case m.memoryUsage:
+			assert.Check(t, is.Equal(100.0, pb.Gauge.GetValue()))
+		case m.swapfile:
+			assert.Check(t, is.Equal("/swap/web", labels["swapfile"]))
+		case m.cpuUsage:
+			assert.Check(t, is.Equal(1.5, pb.Counter.GetValue()))
+		case m.blkio:
+			expected := map[string]float64{"read": 8, "write": 4}
+			assert.Check(t, is.Equal(expected[labels["op"]], pb.Counter.GetValue()))
+		}
+	}
+
+	// the entry of a previous start of the container is replaced already
+	m.del(c.ID, previous)
+	assert.Check(t, is.Len(collectMetrics(m), 9))
+
+	m.del(c.ID, e)
+	assert.Check(t, is.Len(collectMetrics(m), 0))
+}
+
+func collectMetrics(c prometheus.Collector) []prometheus.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	var out []prometheus.Metric
+	for m := range ch {
+		out = append(out, m)
+	}
+	return out
+}
@@ -51,6 +51,13 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		}
 	}
 
+	if params.HostConfig == nil {
+		params.HostConfig = &containertypes.HostConfig{}
+	}
+	// the default swap area of the daemon is verified along with the
+	// settings of the container
+	daemon.setDefaultSwapfile(params.HostConfig)
+
 	warnings, err := daemon.verifyContainerSettings(os, params.HostConfig, params.Config, false)
 	if err != nil {
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
@@ -61,9 +68,6 @@ func (daemon *Daemon) containerCreate(params types.ContainerCreateConfig, manage
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
 
-	if params.HostConfig == nil {
-		params.HostConfig = &containertypes.HostConfig{}
-	}
 	err = daemon.adaptContainerSettings(params.HostConfig, params.AdjustCPUShares)
 	if err != nil {
 		return containertypes.ContainerCreateCreatedBody{Warnings: warnings}, errdefs.InvalidParameter(err)
@@ -267,9 +271,19 @@ func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *i
 	if len(config.Entrypoint) == 0 && len(config.Cmd) == 0 {
 		return fmt.Errorf("No command specified")
 	}
+	if hc := config.Healthcheck; hc != nil && isProbeTest(hc.StartupTest) && !isProbeTest(hc.Test) {
+		// the startup probe only runs as the first phase of the healthcheck
+		return fmt.Errorf("A startup probe requires a healthcheck test, from --health-cmd or the HEALTHCHECK of the image")
+	}
 	return nil
 }
 
+// isProbeTest returns true if test configures a probe, rather than
+// inheriting the one of the image or disabling it.
+func isProbeTest(test []string) bool {
+	return len(test) > 0 && test[0] != "NONE"
+}
+
 // Checks if the client set configurations for more than one network while creating a container
 // Also checks if the IPAMConfig is valid
 func verifyNetworkingConfig(nwConfig *networktypes.NetworkingConfig) error {
@@ -3,9 +3,12 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"testing"
 
+	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/image"
 	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 )
 
 // Test case for 35752
@@ -19,3 +22,30 @@ func TestVerifyNetworkingConfig(t *testing.T) {
 	err := verifyNetworkingConfig(nwConfig)
 	assert.Check(t, errdefs.IsInvalidParameter(err))
 }
+
+func TestMergeAndVerifyConfigStartupProbe(t *testing.T) {
+	daemon := &Daemon{}
+	startup := func(test ...string) *containertypes.Config {
+		return &containertypes.Config{
+			Cmd: []string{"true"},
+			Healthcheck: &containertypes.HealthConfig{
+				Test:        test,
+				StartupTest: []string{"CMD", "true"},
+			},
+		}
+	}
+
+	// a startup probe without a healthcheck never runs
+	err := daemon.mergeAndVerifyConfig(startup(), nil)
+	assert.Check(t, is.ErrorContains(err, "A startup probe requires a healthcheck test"))
+	err = daemon.mergeAndVerifyConfig(startup("NONE"), nil)
+	assert.Check(t, is.ErrorContains(err, "A startup probe requires a healthcheck test"))
+
+	assert.Check(t, daemon.mergeAndVerifyConfig(startup("CMD", "true"), nil))
+
+	// the healthcheck test may come from the image
+	img := &image.Image{V1Image: image.V1Image{Config: &containertypes.Config{
+		Healthcheck: &containertypes.HealthConfig{Test: []string{"CMD", "true"}},
+	}}}
+	assert.Check(t, daemon.mergeAndVerifyConfig(startup(), img))
+}
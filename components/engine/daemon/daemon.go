@@ -46,6 +46,7 @@ import (
 	// register graph drivers
 	_ "github.com/docker/docker/daemon/graphdriver/register"
 	"github.com/docker/docker/daemon/stats"
+	swapservice "github.com/docker/docker/daemon/swap"
 	dmetadata "github.com/docker/docker/distribution/metadata"
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
@@ -93,6 +94,7 @@ type Daemon struct {
 	EventsService     *events.Events
 	netController     libnetwork.NetworkController
 	volumes           *volumesservice.VolumesService
+	swaps             *swapservice.Service
 	discoveryWatcher  discovery.Reloader
 	root              string
 	seccompEnabled    bool
@@ -115,6 +117,23 @@ type Daemon struct {
 
 	machineMemory uint64
 
+	// swapAreas caches the active swap areas of the host for the stats of
+	// the containers
+	swapAreas swapAreaCache
+
+	// swapHost and swapCgroups are used to assign the swap areas of swap
+	// classes, and to repair the swap area assignments of containers
+	swapHost    swapHost
+	swapCgroups swapCgroupReader
+
+	// statsWatchers follow the stats of the running containers for swap
+	// pressure events, idle policies, memory autoscale policies and metrics
+	statsWatchers *statsWatchers
+
+	// containerMetrics exports the resource usage of the running containers
+	// if the metrics api is enabled
+	containerMetrics *containerMetrics
+
 	seccompProfile     []byte
 	seccompProfilePath string
 
@@ -333,6 +352,14 @@ func (daemon *Daemon) restore() error {
 						default:
 							// running
 							c.Lock()
+							if c.Hibernated {
+								// the container was thawed while the daemon was down
+								if err := daemon.restoreMemory(c); err != nil {
+									logrus.WithError(err).WithField("container", c.ID).
+										Error("Failed to restore memory limits of hibernated container")
+								}
+								c.Hibernated = false
+							}
 							c.Paused = false
 							daemon.setStateCounter(c)
 							if err := c.CheckpointTo(daemon.containersReplica); err != nil {
@@ -370,6 +397,13 @@ func (daemon *Daemon) restore() error {
 				}
 
 				c.ResetRestartManager(false)
+				if c.IsRunning() {
+					daemon.reconcileSwap(c)
+					daemon.watchSwapPressure(c)
+					daemon.watchIdle(c)
+					daemon.watchMemoryAutoscale(c)
+					daemon.watchContainerMetrics(c)
+				}
 				if !c.HostConfig.NetworkMode.IsContainer() && c.IsRunning() {
 					options, err := daemon.buildSandboxOptions(c)
 					if err != nil {
@@ -454,12 +488,19 @@ func (daemon *Daemon) restore() error {
 				}
 			}
 
+			// dependencies can take up to dependencyTimeout to reach their
+			// condition: do not hold up the start of the daemon on them
+			if len(c.HostConfig.DependsOn) > 0 {
+				go daemon.restartDependent(c, chNotify, restartContainers)
+				return
+			}
+			defer close(chNotify)
+
 			// Make sure networks are available before starting
 			daemon.waitForNetworks(c)
 			if err := daemon.containerStart(c, "", "", true); err != nil {
 				logrus.Errorf("Failed to start container %s: %s", c.ID, err)
 			}
-			close(chNotify)
 		}(c, notifier)
 
 	}
@@ -893,6 +934,13 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		return nil, err
 	}
 
+	d.swaps, err = swapservice.NewService(filepath.Join(config.Root, "swaps"), d.swapReferences)
+	if err != nil {
+		return nil, err
+	}
+	d.swapHost = hostSwap{}
+	d.swapCgroups = hostCgroups{}
+
 	trustKey, err := loadOrCreateTrustKey(config.TrustKeyPath)
 	if err != nil {
 		return nil, err
@@ -956,6 +1004,10 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	d.execCommands = exec.NewStore()
 	d.idIndex = truncindex.NewTruncIndex([]string{})
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
+	d.statsWatchers = newStatsWatchers()
+	if config.MetricsAddress != "" {
+		d.registerContainerMetrics(config.MetricsContainerLabels)
+	}
 
 	d.EventsService = events.New()
 	d.root = config.Root
@@ -1098,7 +1150,22 @@ func (daemon *Daemon) Shutdown() error {
 	if daemon.containers != nil {
 		logrus.Debugf("daemon configured with a %d seconds minimum shutdown timeout", daemon.configStore.ShutdownTimeout)
 		logrus.Debugf("start clean shutdown of all containers with a %d seconds timeout...", daemon.ShutdownTimeout())
+		// containers are stopped after the containers that depend on them
+		stopped := make(map[string]chan struct{})
+		containers := daemon.containers.List()
+		for _, c := range containers {
+			stopped[c.ID] = make(chan struct{})
+		}
+		dependents := daemon.shutdownDependents(containers)
 		daemon.containers.ApplyAll(func(c *container.Container) {
+			if ch, exists := stopped[c.ID]; exists {
+				defer close(ch)
+			}
+			for _, dependent := range dependents[c.ID] {
+				if ch, exists := stopped[dependent.ID]; exists {
+					<-ch
+				}
+			}
 			if !c.IsRunning() {
 				return
 			}
@@ -1406,6 +1473,32 @@ func fixMemorySwappiness(resources *containertypes.Resources) {
 	}
 }
 
+func fixMemorySwapfile(resources *containertypes.Resources) {
+	if resources.MemorySwapfile != nil && *resources.MemorySwapfile == "" {
+		resources.MemorySwapfile = nil
+	}
+}
+
+const (
+	swapfileDefault = container.SwapfileDefault
+	swapfileAuto    = container.SwapfileAuto
+)
+
+func isAutoSwapfile(swapfile *string) bool {
+	return swapfile != nil && *swapfile == swapfileAuto
+}
+
+// containerResources returns the resources of the container, with the swap
+// area it is assigned to resolved, as they are applied to a running
+// container.
+func containerResources(c *container.Container) containertypes.Resources {
+	resources := c.HostConfig.Resources
+	if swapfile := c.SwapArea(); swapfile != "" {
+		resources.MemorySwapfile = &swapfile
+	}
+	return resources
+}
+
 // GetAttachmentStore returns current attachment store associated with the daemon
 func (daemon *Daemon) GetAttachmentStore() *network.AttachmentStore {
 	return &daemon.attachmentStore
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net"
 	"os"
 	"path/filepath"
@@ -25,12 +26,14 @@ import (
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/daemon/initlayer"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/docker/docker/pkg/containerfs"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/pkg/parsers"
 	"github.com/docker/docker/pkg/parsers/kernel"
+	"github.com/docker/docker/pkg/swap"
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/docker/docker/runconfig"
 	volumemounts "github.com/docker/docker/volume/mounts"
@@ -277,6 +280,18 @@ func checkKernel() error {
 	return nil
 }
 
+// setDefaultSwapfile sets the default swap area of the daemon on a container
+// being created that requests neither a swap area nor a swap class.
+func (daemon *Daemon) setDefaultSwapfile(hostConfig *containertypes.HostConfig) {
+	if daemon.configStore == nil || daemon.configStore.MemorySwapfile == "" {
+		return
+	}
+	if hostConfig.MemorySwapfile == nil && hostConfig.MemorySwapClass == "" {
+		swapfile := daemon.configStore.MemorySwapfile
+		hostConfig.MemorySwapfile = &swapfile
+	}
+}
+
 // adaptContainerSettings is called during container creation to modify any
 // settings necessary in the HostConfig structure.
 func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConfig, adjustCPUShares bool) error {
@@ -292,7 +307,21 @@ func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConf
 	}
 	if hostConfig.Memory > 0 && hostConfig.MemorySwap == 0 {
 		// By default, MemorySwap is set to twice the size of Memory.
-		hostConfig.MemorySwap = hostConfig.Memory * 2
+		ratio := config.DefaultMemorySwapRatio
+		if daemon.configStore != nil && daemon.configStore.MemorySwapRatio != 0 {
+			ratio = daemon.configStore.MemorySwapRatio
+		}
+		if ratio < 0 {
+			hostConfig.MemorySwap = -1
+		} else {
+			hostConfig.MemorySwap = int64(float64(hostConfig.Memory) * ratio)
+		}
+	}
+	if daemon.configStore != nil {
+		if hostConfig.MemorySwappiness == nil && daemon.configStore.MemorySwappiness != nil && *daemon.configStore.MemorySwappiness >= 0 {
+			swappiness := *daemon.configStore.MemorySwappiness
+			hostConfig.MemorySwappiness = &swappiness
+		}
 	}
 	if hostConfig.ShmSize == 0 {
 		hostConfig.ShmSize = config.DefaultShmSize
@@ -355,9 +384,25 @@ func adaptSharedNamespaceContainer(daemon containerGetter, hostConfig *container
 	}
 }
 
+// verifySwapfile checks that path is an active swap area of the host.
+func verifySwapfile(path string) error {
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("Invalid swapfile %q: must be an absolute path, %q or %q", path, swapfileDefault, swapfileAuto)
+	}
+	active, err := swap.IsActive(filepath.Clean(path))
+	if err != nil {
+		return errors.Wrap(err, "failed to read the active swap areas")
+	}
+	if !active {
+		return fmt.Errorf("Invalid swapfile %s: not an active swap area (see /proc/swaps)", path)
+	}
+	return nil
+}
+
 func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysinfo.SysInfo, update bool) ([]string, error) {
 	warnings := []string{}
 	fixMemorySwappiness(resources)
+	fixMemorySwapfile(resources)
 
 	// memory subsystem checks and adjustments
 	if resources.Memory != 0 && resources.Memory < linuxMinMemory {
@@ -391,6 +436,34 @@ func verifyContainerResources(resources *containertypes.Resources, sysInfo *sysi
 			return warnings, fmt.Errorf("Invalid value: %v, valid memory swappiness range is 0-100", swappiness)
 		}
 	}
+	if resources.MemorySwapfile != nil && !sysInfo.MemorySwapfile {
+		// Older clients always send the default swapfile; discard it quietly.
+		if *resources.MemorySwapfile != swapfileDefault {
+			warnings = append(warnings, "Your kernel does not support memory swapfile capabilities or the cgroup is not mounted. Memory swapfile discarded.")
+			logrus.Warn("Your kernel does not support memory swapfile capabilities, or the cgroup is not mounted. Memory swapfile discarded.")
+		}
+		resources.MemorySwapfile = nil
+		resources.MemorySwapfileSize = 0
+	}
+	if isAutoSwapfile(resources.MemorySwapfile) {
+		if update {
+			return warnings, fmt.Errorf("A daemon-managed swapfile can only be requested when creating the container")
+		}
+		if resources.MemorySwapfileSize < linuxMinMemory {
+			return warnings, fmt.Errorf("Minimum swapfile size allowed is 4MB")
+		}
+	} else if resources.MemorySwapfileSize != 0 {
+		return warnings, fmt.Errorf("Swapfile size can only be set together with a daemon-managed (auto) swapfile")
+	}
+	if resources.MemorySwapfile != nil && *resources.MemorySwapfile != swapfileDefault && !isAutoSwapfile(resources.MemorySwapfile) {
+		if err := verifySwapfile(*resources.MemorySwapfile); err != nil {
+			return warnings, err
+		}
+		// store the path as listed in /proc/swaps, so that it can be compared
+		// against the swap areas
+		swapfile := filepath.Clean(*resources.MemorySwapfile)
+		resources.MemorySwapfile = &swapfile
+	}
 	if resources.MemoryReservation > 0 && !sysInfo.MemoryReservation {
 		warnings = append(warnings, "Your kernel does not support memory soft limit capabilities or the cgroup is not mounted. Limitation discarded.")
 		logrus.Warn("Your kernel does not support memory soft limit capabilities or the cgroup is not mounted. Limitation discarded.")
@@ -600,6 +673,32 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
 
+	if err := verifyIdlePolicy(hostConfig.IdlePolicy); err != nil {
+		return warnings, err
+	}
+
+	if err := verifyMemoryAutoscale(hostConfig); err != nil {
+		return warnings, err
+	}
+
+	if err := verifyHooks(hostConfig.Hooks, daemon.configStore.AllowedHooks); err != nil {
+		return warnings, err
+	}
+
+	if hostConfig.MemorySwapClass != "" {
+		if hostConfig.MemorySwapfile != nil {
+			return warnings, fmt.Errorf("Conflicting options: memory swapfile and memory swap class")
+		}
+		if _, ok := daemon.configStore.SwapClasses[hostConfig.MemorySwapClass]; !ok {
+			return warnings, fmt.Errorf("Unknown swap class %s", hostConfig.MemorySwapClass)
+		}
+		if !sysInfo.MemorySwapfile {
+			warnings = append(warnings, "Your kernel does not support memory swapfile capabilities or the cgroup is not mounted. Memory swap class discarded.")
+			logrus.Warn("Your kernel does not support memory swapfile capabilities, or the cgroup is not mounted. Memory swap class discarded.")
+			hostConfig.MemorySwapClass = ""
+		}
+	}
+
 	// ip-forwarding does not affect container with '--net=host' (or '--net=none')
 	if sysInfo.IPv4ForwardingDisabled && !(hostConfig.NetworkMode.IsHost() || hostConfig.NetworkMode.IsNone()) {
 		warnings = append(warnings, "IPv4 forwarding is disabled. Networking will not work.")
@@ -1301,6 +1400,9 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 	if !c.IsRunning() {
 		return nil, errNotRunning(c.ID)
 	}
+	if cgroup2.IsEnabled() {
+		return daemon.statsV2(c)
+	}
 	cs, err := daemon.containerd.Stats(context.Background(), c.ID)
 	if err != nil {
 		if strings.Contains(err.Error(), "container not found") {
@@ -1394,6 +1496,10 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 		}
 	}
 
+	if stats.Memory != nil && stats.Memory.Usage != nil && stats.Memory.Swap != nil {
+		s.SwapStats = getSwapStats(c.SwapArea(), daemon.hostSwapAreas(), stats.Memory.Usage, stats.Memory.Swap)
+	}
+
 	if stats.Pids != nil {
 		s.PidsStats = types.PidsStats{
 			Current: stats.Pids.Current,
@@ -1404,6 +1510,185 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 	return s, nil
 }
 
+// statsV2 reads the stats of a container from its cgroup in the cgroup v2
+// unified hierarchy.
+func (daemon *Daemon) statsV2(c *container.Container) (*types.StatsJSON, error) {
+	dir, err := cgroup2.ProcessPath(c.GetPID())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNotRunning(c.ID)
+		}
+		return nil, err
+	}
+	stats, err := cgroup2.ReadStats(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &types.StatsJSON{}
+	s.Read = time.Now()
+	if stats.CPU != nil {
+		s.CPUStats = types.CPUStats{
+			CPUUsage: types.CPUUsage{
+				TotalUsage:        stats.CPU["usage_usec"] * 1000,
+				UsageInKernelmode: stats.CPU["system_usec"] * 1000,
+				UsageInUsermode:   stats.CPU["user_usec"] * 1000,
+			},
+			ThrottlingData: types.ThrottlingData{
+				Periods:          stats.CPU["nr_periods"],
+				ThrottledPeriods: stats.CPU["nr_throttled"],
+				ThrottledTime:    stats.CPU["throttled_usec"] * 1000,
+			},
+		}
+	}
+
+	if stats.Memory != nil {
+		s.MemoryStats = types.MemoryStats{
+			Stats:   stats.Memory,
+			Usage:   stats.MemoryCurrent,
+			Limit:   stats.MemoryMax,
+			Failcnt: stats.MemoryEvents["max"],
+		}
+		// if the container does not set memory limit, use the machineMemory
+		if s.MemoryStats.Limit > daemon.machineMemory && daemon.machineMemory > 0 {
+			s.MemoryStats.Limit = daemon.machineMemory
+		}
+		s.SwapStats = getSwapStatsV2(c.SwapArea(), daemon.hostSwapAreas(), stats)
+	}
+
+	for _, e := range stats.IO {
+		for _, op := range []struct {
+			name, bytes, ios string
+		}{
+			{"read", "rbytes", "rios"},
+			{"write", "wbytes", "wios"},
+		} {
+			s.BlkioStats.IoServiceBytesRecursive = append(s.BlkioStats.IoServiceBytesRecursive, types.BlkioStatEntry{
+				Major: e.Major,
+				Minor: e.Minor,
+				Op:    op.name,
+				Value: e.Stats[op.bytes],
+			})
+			s.BlkioStats.IoServicedRecursive = append(s.BlkioStats.IoServicedRecursive, types.BlkioStatEntry{
+				Major: e.Major,
+				Minor: e.Minor,
+				Op:    op.name,
+				Value: e.Stats[op.ios],
+			})
+		}
+	}
+
+	s.PidsStats = types.PidsStats{
+		Current: stats.PidsCurrent,
+	}
+	// a limit of 0 means that there is no limit
+	if stats.PidsMax != math.MaxUint64 {
+		s.PidsStats.Limit = stats.PidsMax
+	}
+
+	if stats.CPUPressure != nil || stats.MemoryPressure != nil || stats.IOPressure != nil {
+		s.PressureStats = &types.PressureStats{
+			CPU:    copyPressure(stats.CPUPressure),
+			Memory: copyPressure(stats.MemoryPressure),
+			IO:     copyPressure(stats.IOPressure),
+		}
+	}
+
+	return s, nil
+}
+
+func copyPressure(p *cgroup2.Pressure) *types.Pressure {
+	if p == nil {
+		return nil
+	}
+	out := &types.Pressure{}
+	if p.Some != nil {
+		out.Some = &types.PressureData{Avg10: p.Some.Avg10, Avg60: p.Some.Avg60, Avg300: p.Some.Avg300, Total: p.Some.Total}
+	}
+	if p.Full != nil {
+		out.Full = &types.PressureData{Avg10: p.Full.Avg10, Avg60: p.Full.Avg60, Avg300: p.Full.Avg300, Total: p.Full.Total}
+	}
+	return out
+}
+
+// getSwapStats derives the swap usage of a container from its memory and
+// memory+swap counters, and the swap areas of the host.
+func getSwapStats(swapfile string, areas []swap.Area, mem, memsw *containerd_cgroups.MemoryEntry) types.SwapStats {
+	s := types.SwapStats{
+		MemswUsage:    memsw.Usage,
+		MemswMaxUsage: memsw.Max,
+		MemswFailcnt:  memsw.Failcnt,
+		Swapfile:      swapfile,
+	}
+	if memsw.Usage > mem.Usage {
+		s.Usage = memsw.Usage - mem.Usage
+	}
+	if memsw.Limit > mem.Limit {
+		s.Limit = memsw.Limit - mem.Limit
+	}
+	limitSwapStats(&s, swapfile, areas)
+	return s
+}
+
+// getSwapStatsV2 reads the swap usage of a container from its swap
+// counters in the cgroup v2 unified hierarchy, and the swap areas of the
+// host.
+func getSwapStatsV2(swapfile string, areas []swap.Area, stats *cgroup2.Stats) types.SwapStats {
+	s := types.SwapStats{
+		Usage:      stats.SwapCurrent,
+		Limit:      stats.SwapMax,
+		MemswUsage: stats.MemoryCurrent + stats.SwapCurrent,
+		Swapfile:   swapfile,
+	}
+	limitSwapStats(&s, swapfile, areas)
+	return s
+}
+
+// hostSwapAreas returns the active swap areas of the host, or nil if they
+// cannot be read.
+func (daemon *Daemon) hostSwapAreas() []swap.Area {
+	areas, err := daemon.swapAreas.get()
+	if err != nil {
+		logrus.WithError(err).Debug("failed to read swap areas")
+		return nil
+	}
+	return areas
+}
+
+// limitSwapStats caps the swap limit of a container to the size of the
+// swap areas it can use. Nothing is capped if the swap areas of the host
+// are unknown.
+func limitSwapStats(s *types.SwapStats, swapfile string, areas []swap.Area) {
+	if areas == nil {
+		return
+	}
+	if swapfile != "" {
+		swapfile = filepath.Clean(swapfile)
+	}
+	// the container cannot swap out more than the swap areas it can use
+	var available uint64
+	for _, a := range areas {
+		if swapfile != "" && filepath.Clean(a.Path) != swapfile {
+			continue
+		}
+		available += uint64(a.Size)
+		if swapfile != "" {
+			s.SwapfileSize = uint64(a.Size)
+			s.SwapfileUsed = uint64(a.Used)
+		}
+	}
+	if s.Limit > available {
+		s.Limit = available
+	}
+}
+
+// swapAlertThreshold returns the threshold of swap pressure events, or 0
+// if they are disabled.
+func (daemon *Daemon) swapAlertThreshold() float64 {
+	threshold, _ := daemon.configStore.MemorySwapAlertThreshold()
+	return threshold
+}
+
 // setDefaultIsolation determines the default isolation mode for the
 // daemon to run in. This is only applicable on Windows
 func (daemon *Daemon) setDefaultIsolation() error {
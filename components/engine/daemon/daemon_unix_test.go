@@ -5,14 +5,20 @@ package daemon // import "github.com/docker/docker/daemon"
 import (
 	"errors"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
 
+	containerd_cgroups "github.com/containerd/cgroups"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/blkiodev"
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/pkg/cgroup2"
+	"github.com/docker/docker/pkg/swap"
+	"github.com/docker/docker/pkg/sysinfo"
 	"golang.org/x/sys/unix"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
@@ -96,6 +102,63 @@ func TestAdjustCPUShares(t *testing.T) {
 }
 
 // Unix test as uses settings which are not available on Windows
+func TestAdaptContainerSettingsMemorySwap(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-daemon-unix-test-")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	daemon := &Daemon{
+		repository: tmp,
+		root:       tmp,
+	}
+
+	// without daemon configuration, memory+swap defaults to twice the memory
+	hostConfig := &containertypes.HostConfig{
+		Resources: containertypes.Resources{Memory: 64 * 1024 * 1024},
+	}
+	daemon.setDefaultSwapfile(hostConfig)
+	assert.NilError(t, daemon.adaptContainerSettings(hostConfig, false))
+	assert.Check(t, is.Equal(int64(128*1024*1024), hostConfig.MemorySwap))
+	assert.Check(t, is.Nil(hostConfig.MemorySwappiness))
+	assert.Check(t, is.Nil(hostConfig.MemorySwapfile))
+
+	swappiness := int64(-1)
+	daemon.configStore = &config.Config{
+		MemorySwapfile:   "/swap/nvme0",
+		MemorySwappiness: &swappiness,
+		MemorySwapRatio:  -1,
+	}
+	hostConfig = &containertypes.HostConfig{
+		Resources: containertypes.Resources{Memory: 64 * 1024 * 1024},
+	}
+	daemon.setDefaultSwapfile(hostConfig)
+	assert.NilError(t, daemon.adaptContainerSettings(hostConfig, false))
+	assert.Check(t, is.Equal(int64(-1), hostConfig.MemorySwap))
+	assert.Check(t, is.Nil(hostConfig.MemorySwappiness))
+	assert.Assert(t, hostConfig.MemorySwapfile != nil)
+	assert.Check(t, is.Equal("/swap/nvme0", *hostConfig.MemorySwapfile))
+
+	// explicit container settings take precedence over the daemon defaults
+	swapfile := "default"
+	hostConfig = &containertypes.HostConfig{
+		Resources: containertypes.Resources{
+			Memory:         64 * 1024 * 1024,
+			MemorySwap:     96 * 1024 * 1024,
+			MemorySwapfile: &swapfile,
+		},
+	}
+	daemon.setDefaultSwapfile(hostConfig)
+	assert.NilError(t, daemon.adaptContainerSettings(hostConfig, false))
+	assert.Check(t, is.Equal(int64(96*1024*1024), hostConfig.MemorySwap))
+	assert.Check(t, is.Equal("default", *hostConfig.MemorySwapfile))
+
+	// as are swap classes
+	hostConfig = &containertypes.HostConfig{
+		Resources: containertypes.Resources{MemorySwapClass: "fast"},
+	}
+	daemon.setDefaultSwapfile(hostConfig)
+	assert.Check(t, is.Nil(hostConfig.MemorySwapfile))
+}
+
 func TestAdjustCPUSharesNoAdjustment(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "docker-daemon-unix-test-")
 	if err != nil {
@@ -329,3 +392,115 @@ func TestGetBlkioThrottleDevices(t *testing.T) {
 		assert.Check(t, retDevs[0].Rate == WEIGHT, "get device rate")
 	})
 }
+
+func TestVerifyContainerResourcesAutoSwapfile(t *testing.T) {
+	auto := "auto"
+	path := "/swap/nvme0"
+	sysInfo := &sysinfo.SysInfo{MemorySwapfile: true}
+
+	_, err := verifyContainerResources(&containertypes.Resources{MemorySwapfile: &auto, MemorySwapfileSize: 64 * 1024 * 1024}, sysInfo, false)
+	assert.NilError(t, err)
+
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &auto}, sysInfo, false)
+	assert.Check(t, is.ErrorContains(err, "Minimum swapfile size"))
+
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &auto, MemorySwapfileSize: 64 * 1024 * 1024}, sysInfo, true)
+	assert.Check(t, is.ErrorContains(err, "only be requested when creating"))
+
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &path, MemorySwapfileSize: 64 * 1024 * 1024}, sysInfo, false)
+	assert.Check(t, is.ErrorContains(err, "Swapfile size can only be set"))
+}
+
+func TestVerifyContainerResourcesSwapfile(t *testing.T) {
+	def := "default"
+	path := "/nonexistent/swapfile"
+	relative := "swap/nvme0"
+
+	// without kernel support the swapfile is discarded, with a warning unless
+	// it is the default one
+	resources := &containertypes.Resources{MemorySwapfile: &path}
+	warnings, err := verifyContainerResources(resources, &sysinfo.SysInfo{}, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(warnings, 1))
+	assert.Check(t, is.Nil(resources.MemorySwapfile))
+
+	resources = &containertypes.Resources{MemorySwapfile: &def}
+	warnings, err = verifyContainerResources(resources, &sysinfo.SysInfo{}, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(warnings, 0))
+	assert.Check(t, is.Nil(resources.MemorySwapfile))
+
+	sysInfo := &sysinfo.SysInfo{MemorySwapfile: true}
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &def}, sysInfo, false)
+	assert.NilError(t, err)
+
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &relative}, sysInfo, false)
+	assert.Check(t, is.ErrorContains(err, "must be an absolute path"))
+
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &path}, sysInfo, false)
+	assert.Check(t, is.ErrorContains(err, "not an active swap area"))
+
+	areas, err := swap.GetAreas()
+	if err != nil || len(areas) == 0 {
+		t.Skip("no active swap area on the host")
+	}
+	_, err = verifyContainerResources(&containertypes.Resources{MemorySwapfile: &areas[0].Path}, sysInfo, false)
+	assert.NilError(t, err)
+
+	// non-clean paths are stored as listed in /proc/swaps
+	dir, file := filepath.Split(areas[0].Path)
+	unclean := dir + "./" + file
+	resources = &containertypes.Resources{MemorySwapfile: &unclean}
+	_, err = verifyContainerResources(resources, sysInfo, false)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(areas[0].Path, *resources.MemorySwapfile))
+}
+
+func TestGetSwapStats(t *testing.T) {
+	mem := &containerd_cgroups.MemoryEntry{Usage: 100, Limit: 1000}
+	memsw := &containerd_cgroups.MemoryEntry{Usage: 150, Max: 200, Failcnt: 3, Limit: 2000}
+
+	s := getSwapStats("/nonexistent/swapfile", []swap.Area{}, mem, memsw)
+	assert.Check(t, is.Equal(uint64(50), s.Usage))
+	assert.Check(t, is.Equal(uint64(150), s.MemswUsage))
+	assert.Check(t, is.Equal(uint64(200), s.MemswMaxUsage))
+	assert.Check(t, is.Equal(uint64(3), s.MemswFailcnt))
+	assert.Check(t, is.Equal("/nonexistent/swapfile", s.Swapfile))
+	// the swapfile is not an active swap area, so the container cannot swap
+	assert.Check(t, is.Equal(uint64(0), s.Limit))
+	assert.Check(t, is.Equal(uint64(0), s.SwapfileSize))
+}
+
+func TestLimitSwapStats(t *testing.T) {
+	areas := []swap.Area{
+		{Path: "/swap/a", Size: 100, Used: 10},
+		{Path: "/swap/b", Size: 200, Used: 20},
+	}
+
+	s := types.SwapStats{Limit: 1000}
+	limitSwapStats(&s, "", areas)
+	assert.Check(t, is.Equal(uint64(300), s.Limit))
+
+	// the swapfile of the container is compared as a clean path
+	s = types.SwapStats{Limit: 1000}
+	limitSwapStats(&s, "/swap//b", areas)
+	assert.Check(t, is.Equal(uint64(200), s.Limit))
+	assert.Check(t, is.Equal(uint64(200), s.SwapfileSize))
+	assert.Check(t, is.Equal(uint64(20), s.SwapfileUsed))
+
+	// nothing is capped if the swap areas are unknown
+	s = types.SwapStats{Limit: 1000}
+	limitSwapStats(&s, "/swap/a", nil)
+	assert.Check(t, is.Equal(uint64(1000), s.Limit))
+}
+
+func TestGetSwapStatsV2(t *testing.T) {
+	stats := &cgroup2.Stats{MemoryCurrent: 100, SwapCurrent: 50, SwapMax: math.MaxUint64}
+
+	s := getSwapStatsV2("/nonexistent/swapfile", []swap.Area{}, stats)
+	assert.Check(t, is.Equal(uint64(50), s.Usage))
+	assert.Check(t, is.Equal(uint64(150), s.MemswUsage))
+	assert.Check(t, is.Equal("/nonexistent/swapfile", s.Swapfile))
+	// the swapfile is not an active swap area, so the container cannot swap
+	assert.Check(t, is.Equal(uint64(0), s.Limit))
+}
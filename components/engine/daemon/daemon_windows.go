@@ -65,6 +65,10 @@ func (daemon *Daemon) getCgroupDriver() string {
 	return ""
 }
 
+// setDefaultSwapfile is a no-op on Windows, which does not support swap areas.
+func (daemon *Daemon) setDefaultSwapfile(hostConfig *containertypes.HostConfig) {
+}
+
 // adaptContainerSettings is called during container creation to modify any
 // settings necessary in the HostConfig structure.
 func (daemon *Daemon) adaptContainerSettings(hostConfig *containertypes.HostConfig, adjustCPUShares bool) error {
@@ -204,6 +208,18 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("Windows client operating systems earlier than version 1809 can only run Hyper-V containers")
 	}
 
+	if hostConfig.IdlePolicy != nil {
+		return warnings, fmt.Errorf("Windows does not support idle policies")
+	}
+
+	if hostConfig.MemoryAutoscale != nil {
+		return warnings, fmt.Errorf("Windows does not support memory autoscale")
+	}
+
+	if hostConfig.Hooks != nil {
+		return warnings, fmt.Errorf("Windows does not support container hooks")
+	}
+
 	w, err := verifyContainerResources(&hostConfig.Resources, hyperv)
 	warnings = append(warnings, w...)
 	return warnings, err
@@ -593,6 +609,12 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 	return s, nil
 }
 
+// swapAlertThreshold returns 0 as swap pressure events are not supported on
+// Windows.
+func (daemon *Daemon) swapAlertThreshold() float64 {
+	return 0
+}
+
 // setDefaultIsolation determine the default isolation mode for the
 // daemon to run in. This is only applicable on Windows
 func (daemon *Daemon) setDefaultIsolation() error {
@@ -77,6 +77,16 @@ func (daemon *Daemon) rmLink(container *container.Container, name string) error
 // cleanupContainer unregisters a container from the daemon, stops stats
 // collection and cleanly removes contents and metadata from the filesystem.
 func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemove, removeVolume bool) (err error) {
+	if !forceRemove {
+		if dependents := daemon.dependents(container); len(dependents) > 0 {
+			var names []string
+			for _, d := range dependents {
+				names = append(names, containerName(d))
+			}
+			err := fmt.Errorf("You cannot remove container %s, which containers %s depend on. Remove them before attempting removal or force remove", container.ID, strings.Join(names, ", "))
+			return errdefs.Conflict(err)
+		}
+	}
 	if container.IsRunning() {
 		if !forceRemove {
 			state := container.StateString()
@@ -127,6 +137,12 @@ func (daemon *Daemon) cleanupContainer(container *container.Container, forceRemo
 		container.RWLayer = nil
 	}
 
+	if err := daemon.removeSwapfile(container); err != nil {
+		err = errors.Wrapf(err, "container %s", container.ID)
+		container.SetRemovalError(err)
+		return err
+	}
+
 	if err := system.EnsureRemoveAll(container.Root); err != nil {
 		e := errors.Wrapf(err, "unable to remove filesystem for %s", container.ID)
 		container.SetRemovalError(e)
@@ -5,8 +5,10 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -56,6 +58,8 @@ type probe interface {
 
 // cmdProbe implements the "CMD" probe type.
 type cmdProbe struct {
+	// The healthcheck test, starting with the probe type.
+	test strslice.StrSlice
 	// Run the command with the system's default shell instead of execing it directly.
 	shell bool
 }
@@ -63,7 +67,7 @@ type cmdProbe struct {
 // exec the healthcheck command in the container.
 // Returns the exit code and probe output (if any)
 func (p *cmdProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
-	cmdSlice := strslice.StrSlice(cntr.Config.Healthcheck.Test)[1:]
+	cmdSlice := p.test[1:]
 	if p.shell {
 		cmdSlice = append(getShell(cntr.Config), cmdSlice...)
 	}
@@ -134,11 +138,7 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 	h := c.State.Health
 	oldStatus := h.Status()
 
-	if len(h.Log) >= maxLogEntries {
-		h.Log = append(h.Log[len(h.Log)+1-maxLogEntries:], result)
-	} else {
-		h.Log = append(h.Log, result)
-	}
+	appendHealthLog(h, result)
 
 	if result.ExitCode == exitStatusHealthy {
 		h.FailingStreak = 0
@@ -179,27 +179,105 @@ func handleProbeResult(d *Daemon, c *container.Container, result *types.Healthch
 	current := h.Status()
 	if oldStatus != current {
 		d.LogContainerEvent(c, "health_status: "+current)
+		if current == types.Unhealthy && c.HostConfig != nil && c.HostConfig.RestartPolicy.OnUnhealthy {
+			go d.stopUnhealthy(c)
+		}
+	}
+}
+
+// Update the container's Status.Health struct based on the latest result of
+// its startup probe. Failures are recorded, but do not count towards the
+// failing streak; the first success lets the healthcheck start.
+func handleStartupResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
+	c.Lock()
+	defer c.Unlock()
+
+	// probe may have been cancelled while waiting on lock. Ignore result then
+	select {
+	case <-done:
+		return
+	default:
+	}
+
+	h := c.State.Health
+	appendHealthLog(h, result)
+	if result.ExitCode == exitStatusHealthy {
+		logrus.Debugf("Startup probe for container %s succeeded", c.ID)
+		h.SetStartupDone(true)
+	}
+
+	if err := c.CheckpointTo(d.containersReplica); err != nil {
+		logrus.Errorf("Error replicating health state for container %s: %v", c.ID, err)
+	}
+}
+
+// Record a probe result in the health log, dropping the oldest entries.
+func appendHealthLog(h *container.Health, result *types.HealthcheckResult) {
+	if len(h.Log) >= maxLogEntries {
+		h.Log = append(h.Log[len(h.Log)+1-maxLogEntries:], result)
+	} else {
+		h.Log = append(h.Log, result)
+	}
+}
+
+// stopUnhealthy stops a container that became unhealthy, for its restart
+// policy to restart it. Unlike "docker stop", it leaves the restart policy
+// of the container in effect.
+func (d *Daemon) stopUnhealthy(c *container.Container) {
+	if c.IsPaused() {
+		return
+	}
+	c.RestartManager().SetUnhealthy()
+
+	stopSignal := c.StopSignal()
+	logrus.WithField("container", c.ID).Infof("Stopping unhealthy container to restart it")
+	d.LogContainerEventWithAttributes(c, "kill", map[string]string{
+		"signal": strconv.Itoa(stopSignal),
+		"reason": types.Unhealthy,
+	})
+	if err := d.kill(c, stopSignal); err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Warn("failed to stop unhealthy container")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.StopTimeout())*time.Second)
+	defer cancel()
+	if status := <-c.Wait(ctx, container.WaitConditionNotRunning); status.Err() != nil {
+		// the container did not exit on its stop signal
+		if err := d.kill(c, int(syscall.SIGKILL)); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Warn("failed to kill unhealthy container")
+		}
 	}
 }
 
 // Run the container's monitoring thread until notified via "stop".
 // There is never more than one monitor thread running per container at a time.
-func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe) {
+// If the container has a startup probe, it runs until its first success
+// before the healthcheck probe.
+func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe, startup probe) {
 	probeTimeout := timeoutWithDefault(c.Config.Healthcheck.Timeout, defaultProbeTimeout)
 	probeInterval := timeoutWithDefault(c.Config.Healthcheck.Interval, defaultProbeInterval)
+	startupInterval := timeoutWithDefault(c.Config.Healthcheck.StartupInterval, probeInterval)
 	for {
+		if startup != nil && c.State.Health.StartupDone() {
+			startup = nil
+		}
+		current, interval, handleResult := probe, probeInterval, handleProbeResult
+		if startup != nil {
+			current, interval, handleResult = startup, startupInterval, handleStartupResult
+		}
+
 		select {
 		case <-stop:
 			logrus.Debugf("Stop healthcheck monitoring for container %s (received while idle)", c.ID)
 			return
-		case <-time.After(probeInterval):
+		case <-time.After(interval):
 			logrus.Debugf("Running health check for container %s ...", c.ID)
 			startTime := time.Now()
 			ctx, cancelProbe := context.WithTimeout(context.Background(), probeTimeout)
 			results := make(chan *types.HealthcheckResult, 1)
 			go func() {
 				healthChecksCounter.Inc()
-				result, err := probe.run(ctx, d, c)
+				result, err := current.run(ctx, d, c)
 				if err != nil {
 					healthChecksFailedCounter.Inc()
 					logrus.Warnf("Health check for container %s error: %v", c.ID, err)
@@ -225,12 +303,12 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 				<-results
 				return
 			case result := <-results:
-				handleProbeResult(d, c, result, stop)
+				handleResult(d, c, result, stop)
 				// Stop timeout
 				cancelProbe()
 			case <-ctx.Done():
 				logrus.Debugf("Health check for container %s taking too long", c.ID)
-				handleProbeResult(d, c, &types.HealthcheckResult{
+				handleResult(d, c, &types.HealthcheckResult{
 					ExitCode: -1,
 					Output:   fmt.Sprintf("Health check exceeded timeout (%v)", probeTimeout),
 					Start:    startTime,
@@ -249,18 +327,41 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 // Nil will be returned if no healthcheck was configured or NONE was set.
 func getProbe(c *container.Container) probe {
 	config := c.Config.Healthcheck
-	if config == nil || len(config.Test) == 0 {
+	if config == nil {
+		return nil
+	}
+	return probeForTest(c, config.Test)
+}
+
+// Get a suitable probe implementation for the container's startup probe.
+// Nil will be returned if no startup probe was configured or NONE was set.
+func getStartupProbe(c *container.Container) probe {
+	config := c.Config.Healthcheck
+	if config == nil {
 		return nil
 	}
-	switch config.Test[0] {
+	return probeForTest(c, config.StartupTest)
+}
+
+func probeForTest(c *container.Container, test []string) probe {
+	if len(test) == 0 {
+		return nil
+	}
+	switch test[0] {
 	case "CMD":
-		return &cmdProbe{shell: false}
+		return &cmdProbe{test: test, shell: false}
 	case "CMD-SHELL":
-		return &cmdProbe{shell: true}
+		return &cmdProbe{test: test, shell: true}
+	case "HTTP-GET", "TCP", "GRPC":
+		if err := validateProbeTest(test); err != nil {
+			logrus.Warnf("Invalid healthcheck in container %s: %v", c.ID, err)
+			return nil
+		}
+		return newNetProbe(test)
 	case "NONE":
 		return nil
 	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", test[0], c.ID)
 		return nil
 	}
 }
@@ -278,7 +379,11 @@ func (d *Daemon) updateHealthMonitor(c *container.Container) {
 	wantRunning := c.Running && !c.Paused && probe != nil
 	if wantRunning {
 		if stop := h.OpenMonitorChannel(); stop != nil {
-			go monitor(d, c, stop, probe)
+			startup := getStartupProbe(c)
+			if h.StartupDone() {
+				startup = nil
+			}
+			go monitor(d, c, stop, probe, startup)
 		}
 	} else {
 		h.CloseMonitorChannel()
@@ -300,6 +405,7 @@ func (d *Daemon) initHealthMonitor(c *container.Container) {
 
 	if h := c.State.Health; h != nil {
 		h.SetStatus(types.Starting)
+		h.SetStartupDone(false)
 		h.FailingStreak = 0
 	} else {
 		h := &container.Health{}
@@ -0,0 +1,193 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// validateProbeTest validates the test of a healthcheck performed by the
+// daemon itself (HTTP-GET, TCP or GRPC), rather than by a command run in the
+// container.
+func validateProbeTest(test []string) error {
+	if len(test) == 0 {
+		return nil
+	}
+	args := test[1:]
+	switch test[0] {
+	case "HTTP-GET":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("HTTP-GET healthcheck requires a port and an optional path")
+		}
+		if len(args) == 2 && !strings.HasPrefix(args[1], "/") {
+			return fmt.Errorf("HTTP-GET healthcheck path must start with /")
+		}
+	case "TCP":
+		if len(args) != 1 {
+			return fmt.Errorf("TCP healthcheck requires a port")
+		}
+	case "GRPC":
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("GRPC healthcheck requires a port and an optional service")
+		}
+	default:
+		return nil
+	}
+	if port, err := strconv.Atoi(args[0]); err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("invalid %s healthcheck port %q", test[0], args[0])
+	}
+	return nil
+}
+
+// newNetProbe returns the probe of a validated HTTP-GET, TCP or GRPC test.
+func newNetProbe(test []string) probe {
+	switch test[0] {
+	case "HTTP-GET":
+		p := &httpProbe{port: test[1], path: "/"}
+		if len(test) > 2 {
+			p.path = test[2]
+		}
+		return p
+	case "TCP":
+		return &tcpProbe{port: test[1]}
+	default:
+		p := &grpcProbe{port: test[1]}
+		if len(test) > 2 {
+			p.service = test[2]
+		}
+		return p
+	}
+}
+
+// httpProbe implements the "HTTP-GET" probe type. The container is healthy
+// if the response has a 2xx or 3xx status code.
+type httpProbe struct {
+	port string
+	path string
+}
+
+func (p *httpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialContainer(ctx, cntr, p.port)
+			},
+			DisableKeepAlives: true,
+		},
+		// redirections could only lead back to the container
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:"+p.port+p.path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Docker-Healthcheck")
+
+	start := time.Now()
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return probeFailure(start, err), nil
+	}
+	defer resp.Body.Close()
+	latency := time.Since(start)
+
+	output := &limitedBuffer{}
+	io.Copy(output, io.LimitReader(resp.Body, maxOutputLen+1))
+
+	exitCode := 1
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusBadRequest {
+		exitCode = exitStatusHealthy
+	}
+	return &types.HealthcheckResult{
+		End:        time.Now(),
+		ExitCode:   exitCode,
+		Output:     output.String(),
+		StatusCode: resp.StatusCode,
+		Latency:    latency,
+	}, nil
+}
+
+// tcpProbe implements the "TCP" probe type. The container is healthy if a
+// connection to the port can be established.
+type tcpProbe struct {
+	port string
+}
+
+func (p *tcpProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	start := time.Now()
+	conn, err := dialContainer(ctx, cntr, p.port)
+	if err != nil {
+		return probeFailure(start, err), nil
+	}
+	latency := time.Since(start)
+	conn.Close()
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Latency:  latency,
+	}, nil
+}
+
+// grpcProbe implements the "GRPC" probe type. The container is healthy if
+// the gRPC health service on the port reports the service as serving.
+type grpcProbe struct {
+	port    string
+	service string
+}
+
+func (p *grpcProbe) run(ctx context.Context, d *Daemon, cntr *container.Container) (*types.HealthcheckResult, error) {
+	start := time.Now()
+	conn, err := grpc.DialContext(ctx, "localhost:"+p.port,
+		grpc.WithInsecure(),
+		grpc.WithBlock(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return dialContainer(ctx, cntr, p.port)
+		}),
+	)
+	if err != nil {
+		return probeFailure(start, err), nil
+	}
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: p.service})
+	if err != nil {
+		return probeFailure(start, err), nil
+	}
+	latency := time.Since(start)
+
+	exitCode := 1
+	if resp.Status == healthpb.HealthCheckResponse_SERVING {
+		exitCode = exitStatusHealthy
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitCode,
+		Output:   resp.Status.String(),
+		Latency:  latency,
+	}, nil
+}
+
+// probeFailure returns the result of a probe that could not get a response
+// from the container, which is then unhealthy.
+func probeFailure(start time.Time, err error) *types.HealthcheckResult {
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: 1,
+		Output:   err.Error(),
+		Latency:  time.Since(start),
+	}
+}
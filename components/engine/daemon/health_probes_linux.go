@@ -0,0 +1,65 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/docker/docker/container"
+	"github.com/vishvananda/netns"
+)
+
+// dialContainer connects to a TCP port of a container from within its
+// network namespace, so that ports that are not published can be probed.
+// The namespace is the one of the container process rather than its sandbox,
+// which containers sharing the network of another container do not have.
+func dialContainer(ctx context.Context, c *container.Container, port string) (net.Conn, error) {
+	pid := c.State.GetPID()
+	if pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", c.ID)
+	}
+	nsPath := fmt.Sprintf("/proc/%d/ns/net", pid)
+
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	ch := make(chan dialResult, 1)
+	go func() {
+		// the socket is created in the network namespace of the thread, and
+		// stays in it once the thread is moved back
+		runtime.LockOSThread()
+		origin, err := netns.Get()
+		if err != nil {
+			runtime.UnlockOSThread()
+			ch <- dialResult{err: fmt.Errorf("failed to get the network namespace of the daemon: %v", err)}
+			return
+		}
+		defer origin.Close()
+		ns, err := netns.GetFromPath(nsPath)
+		if err != nil {
+			runtime.UnlockOSThread()
+			ch <- dialResult{err: fmt.Errorf("failed to open the network namespace of container %s: %v", c.ID, err)}
+			return
+		}
+		defer ns.Close()
+		if err := netns.Set(ns); err != nil {
+			runtime.UnlockOSThread()
+			ch <- dialResult{err: fmt.Errorf("failed to enter the network namespace of container %s: %v", c.ID, err)}
+			return
+		}
+
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort("127.0.0.1", port))
+
+		// the thread is left locked, and so is terminated with the goroutine,
+		// if it cannot be moved back to the namespace of the daemon
+		if err := netns.Set(origin); err == nil {
+			runtime.UnlockOSThread()
+		}
+		ch <- dialResult{conn: conn, err: err}
+	}()
+	r := <-ch
+	return r.conn, r.err
+}
@@ -0,0 +1,62 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestNetProbes(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("root required")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NilError(t, err)
+
+	// probe the network namespace of the test
+	c := &container.Container{
+		ID:     "container_id",
+		State:  container.NewState(),
+		Config: &containertypes.Config{},
+	}
+	c.State.Pid = os.Getpid()
+
+	result, err := (&httpProbe{port: port, path: "/healthz"}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(0, result.ExitCode))
+	assert.Check(t, is.Equal(http.StatusOK, result.StatusCode))
+	assert.Check(t, is.Equal("ok", result.Output))
+	assert.Check(t, result.Latency > 0)
+
+	result, err = (&httpProbe{port: port, path: "/"}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(1, result.ExitCode))
+	assert.Check(t, is.Equal(http.StatusServiceUnavailable, result.StatusCode))
+
+	result, err = (&tcpProbe{port: port}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(0, result.ExitCode))
+
+	server.Close()
+	result, err = (&tcpProbe{port: port}).run(context.Background(), nil, c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(1, result.ExitCode))
+	assert.Check(t, is.Contains(result.Output, "connection refused"))
+}
@@ -0,0 +1,42 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestValidateProbeTest(t *testing.T) {
+	testCases := []struct {
+		test     []string
+		expected string
+	}{
+		{test: []string{"CMD", "true"}},
+		{test: []string{"HTTP-GET", "8080"}},
+		{test: []string{"HTTP-GET", "8080", "/healthz"}},
+		{test: []string{"TCP", "5432"}},
+		{test: []string{"GRPC", "50051", "my.Service"}},
+		{test: []string{"HTTP-GET"}, expected: "HTTP-GET healthcheck requires a port and an optional path"},
+		{test: []string{"HTTP-GET", "8080", "healthz"}, expected: "HTTP-GET healthcheck path must start with /"},
+		{test: []string{"TCP", "5432", "5433"}, expected: "TCP healthcheck requires a port"},
+		{test: []string{"GRPC"}, expected: "GRPC healthcheck requires a port and an optional service"},
+		{test: []string{"TCP", "http"}, expected: `invalid TCP healthcheck port "http"`},
+		{test: []string{"TCP", "65536"}, expected: `invalid TCP healthcheck port "65536"`},
+	}
+	for _, tc := range testCases {
+		err := validateProbeTest(tc.test)
+		if tc.expected == "" {
+			assert.Check(t, err, tc.test)
+			continue
+		}
+		assert.Check(t, is.Error(err, tc.expected), tc.test)
+	}
+}
+
+func TestNewNetProbe(t *testing.T) {
+	assert.Check(t, is.Equal(httpProbe{port: "8080", path: "/"}, *newNetProbe([]string{"HTTP-GET", "8080"}).(*httpProbe)))
+	assert.Check(t, is.Equal(httpProbe{port: "8080", path: "/healthz"}, *newNetProbe([]string{"HTTP-GET", "8080", "/healthz"}).(*httpProbe)))
+	assert.Check(t, is.Equal(tcpProbe{port: "5432"}, *newNetProbe([]string{"TCP", "5432"}).(*tcpProbe)))
+	assert.Check(t, is.Equal(grpcProbe{port: "50051", service: "my.Service"}, *newNetProbe([]string{"GRPC", "50051", "my.Service"}).(*grpcProbe)))
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/docker/docker/container"
+)
+
+func dialContainer(ctx context.Context, c *container.Container, port string) (net.Conn, error) {
+	return nil, errors.New("HTTP-GET, TCP and GRPC healthchecks are not supported on this platform")
+}
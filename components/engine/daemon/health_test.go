@@ -152,3 +152,64 @@ func TestHealthStates(t *testing.T) {
 		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
 	}
 }
+
+func TestHealthStartupProbe(t *testing.T) {
+	c := &container.Container{
+		ID:   "container_id",
+		Name: "container_name",
+		Config: &containertypes.Config{
+			Image: "image_name",
+			Healthcheck: &containertypes.HealthConfig{
+				Test:        []string{"CMD", "true"},
+				StartupTest: []string{"TCP", "8080"},
+				Retries:     1,
+			},
+		},
+	}
+
+	store, err := container.NewViewDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemon := &Daemon{
+		EventsService:     events.New(),
+		containersReplica: store,
+	}
+
+	if getStartupProbe(c) == nil {
+		t.Fatal("Expecting a startup probe, but got none")
+	}
+
+	reset(c)
+	handleResult := func(exitCode int) {
+		handleStartupResult(daemon, c, &types.HealthcheckResult{
+			Start:    c.State.StartedAt.Add(time.Second),
+			End:      c.State.StartedAt.Add(time.Second),
+			ExitCode: exitCode,
+		}, nil)
+	}
+
+	// failures of the startup probe do not count towards the failing streak
+	handleResult(1)
+	handleResult(1)
+	if status := c.State.Health.Status(); status != types.Starting {
+		t.Errorf("Expecting starting, but got %#v\n", status)
+	}
+	if c.State.Health.FailingStreak != 0 {
+		t.Errorf("Expecting FailingStreak=0, but got %d\n", c.State.Health.FailingStreak)
+	}
+	if c.State.Health.StartupDone() {
+		t.Error("Expecting the startup probe to be running")
+	}
+	if len(c.State.Health.Log) != 2 {
+		t.Errorf("Expecting 2 log entries, but got %d\n", len(c.State.Health.Log))
+	}
+
+	handleResult(0)
+	if !c.State.Health.StartupDone() {
+		t.Error("Expecting the startup probe to be done")
+	}
+	if status := c.State.Health.Status(); status != types.Starting {
+		t.Errorf("Expecting starting, but got %#v\n", status)
+	}
+}
@@ -0,0 +1,122 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ContainerHibernate freezes a container and pushes its memory out to swap.
+func (daemon *Daemon) ContainerHibernate(name string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	return daemon.containerHibernate(container)
+}
+
+// containerHibernate freezes the container, and lowers its memory limit so
+// that the kernel reclaims its memory, swapping it out to the swap area the
+// container is assigned to. The container can be brought back by calling
+// containerResume.
+func (daemon *Daemon) containerHibernate(container *container.Container) error {
+	container.Lock()
+	defer container.Unlock()
+
+	// We cannot hibernate the container which is not running
+	if !container.Running {
+		return errNotRunning(container.ID)
+	}
+
+	// We cannot hibernate the container which is already hibernated
+	if container.Hibernated {
+		return errdefs.Conflict(errors.Errorf("Container %s is already hibernated", container.ID))
+	}
+
+	// We cannot hibernate the container which is paused
+	if container.Paused {
+		return errdefs.Conflict(errors.Errorf("Container %s is paused, unpause the container before hibernating", container.ID))
+	}
+
+	// We cannot hibernate the container which is restarting
+	if container.Restarting {
+		return errContainerIsRestarting(container.ID)
+	}
+
+	if err := verifyHibernate(container); err != nil {
+		return err
+	}
+
+	if err := daemon.containerd.Pause(context.Background(), container.ID); err != nil {
+		return fmt.Errorf("Cannot hibernate container %s: %s", container.ID, err)
+	}
+
+	if err := daemon.reclaimMemory(container); err != nil {
+		if err := daemon.restoreMemory(container); err != nil {
+			logrus.WithError(err).WithField("container", container.ID).Warn("failed to restore memory limits")
+		}
+		if err := daemon.containerd.Resume(context.Background(), container.ID); err != nil {
+			logrus.WithError(err).WithField("container", container.ID).Warn("failed to thaw container")
+		}
+		return errors.Wrapf(err, "Cannot hibernate container %s", container.ID)
+	}
+
+	container.Paused = true
+	container.Hibernated = true
+	// the memory limits are restored when the container is resumed
+	container.Idle = false
+	daemon.setStateCounter(container)
+	daemon.updateHealthMonitor(container)
+	daemon.LogContainerEvent(container, "hibernate")
+
+	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
+		logrus.WithError(err).Warn("could not save container to disk")
+	}
+
+	return nil
+}
+
+// ContainerResume resumes a hibernated container
+func (daemon *Daemon) ContainerResume(name string) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+	return daemon.containerResume(container)
+}
+
+// containerResume restores the memory limits of a hibernated container, and
+// thaws it.
+func (daemon *Daemon) containerResume(container *container.Container) error {
+	container.Lock()
+	defer container.Unlock()
+
+	// We cannot resume the container which is not hibernated
+	if !container.Hibernated {
+		return errdefs.Conflict(errors.Errorf("Container %s is not hibernated", container.ID))
+	}
+
+	if err := daemon.restoreMemory(container); err != nil {
+		return errors.Wrapf(err, "Cannot resume container %s", container.ID)
+	}
+
+	if err := daemon.containerd.Resume(context.Background(), container.ID); err != nil {
+		return fmt.Errorf("Cannot resume container %s: %s", container.ID, err)
+	}
+
+	container.Paused = false
+	container.Hibernated = false
+	daemon.setStateCounter(container)
+	daemon.updateHealthMonitor(container)
+	daemon.LogContainerEvent(container, "resume")
+
+	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
+		logrus.WithError(err).Warn("could not save container to disk")
+	}
+
+	return nil
+}
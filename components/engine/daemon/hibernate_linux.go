@@ -0,0 +1,89 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/cgroup2"
+	"github.com/pkg/errors"
+)
+
+// hibernateMemoryLimit is the memory limit a container is first shrunk to
+// when hibernating it. If the kernel cannot reclaim enough memory to honor
+// it, the limit is doubled until it succeeds.
+const hibernateMemoryLimit = linuxMinMemory
+
+// verifyHibernate checks that the memory of the container can be swapped out.
+func verifyHibernate(c *container.Container) error {
+	r := c.HostConfig.Resources
+	if r.MemorySwap > 0 && r.MemorySwap == r.Memory {
+		return errdefs.InvalidParameter(errors.Errorf("Container %s cannot be hibernated: its memory+swap limit equals its memory limit", c.ID))
+	}
+	if r.MemorySwappiness != nil && *r.MemorySwappiness == 0 {
+		return errdefs.InvalidParameter(errors.Errorf("Container %s cannot be hibernated: its memory swappiness is 0", c.ID))
+	}
+	return nil
+}
+
+// reclaimMemory lowers the memory limit of a frozen container, forcing the
+// kernel to reclaim its memory. Anonymous memory is swapped out to the swap
+// area the container is assigned to. Callers must hold the container lock.
+func (daemon *Daemon) reclaimMemory(c *container.Container) error {
+	usage, err := daemon.memoryUsage(c)
+	if err != nil {
+		return err
+	}
+
+	resources := containerResources(c)
+	for limit := int64(hibernateMemoryLimit); limit < usage; limit *= 2 {
+		resources.Memory = limit
+		err = daemon.containerd.UpdateResources(context.Background(), c.ID, toContainerdResources(resources))
+		if err == nil {
+			return nil
+		}
+	}
+	if err != nil {
+		return errors.Wrap(errdefs.System(err), "failed to reclaim memory")
+	}
+	// the container uses less memory than hibernateMemoryLimit already
+	return nil
+}
+
+// memoryUsage returns the memory usage of a running container. Callers must
+// hold the container lock.
+func (daemon *Daemon) memoryUsage(c *container.Container) (int64, error) {
+	if cgroup2.IsEnabled() {
+		dir, err := cgroup2.ProcessPath(c.Pid)
+		if err != nil {
+			return 0, err
+		}
+		stats, err := cgroup2.ReadStats(dir)
+		if err != nil {
+			return 0, err
+		}
+		return int64(stats.MemoryCurrent), nil
+	}
+	cs, err := daemon.containerd.Stats(context.Background(), c.ID)
+	if err != nil {
+		return 0, err
+	}
+	if cs.Metrics != nil && cs.Metrics.Memory != nil && cs.Metrics.Memory.Usage != nil {
+		return int64(cs.Metrics.Memory.Usage.Usage), nil
+	}
+	return 0, nil
+}
+
+// restoreMemory restores the memory limits of the container from its host
+// config. Callers must hold the container lock.
+func (daemon *Daemon) restoreMemory(c *container.Container) error {
+	resources := containerResources(c)
+	if resources.Memory == 0 {
+		// lift the limit that was set by reclaimMemory
+		resources.Memory = -1
+	}
+	if err := daemon.containerd.UpdateResources(context.Background(), c.ID, toContainerdResources(resources)); err != nil {
+		return errdefs.System(err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVerifyHibernate(t *testing.T) {
+	swappiness := func(v int64) *int64 { return &v }
+
+	testCases := []struct {
+		doc       string
+		resources containertypes.Resources
+		expected  string
+	}{
+		{
+			doc: "no limits",
+		},
+		{
+			doc:       "swap limit",
+			resources: containertypes.Resources{Memory: 1024, MemorySwap: 2048},
+		},
+		{
+			doc:       "unlimited swap",
+			resources: containertypes.Resources{Memory: 1024, MemorySwap: -1},
+		},
+		{
+			doc:       "swap disabled",
+			resources: containertypes.Resources{Memory: 1024, MemorySwap: 1024},
+			expected:  "its memory+swap limit equals its memory limit",
+		},
+		{
+			doc:       "swappiness 0",
+			resources: containertypes.Resources{MemorySwappiness: swappiness(0)},
+			expected:  "its memory swappiness is 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			c := &container.Container{
+				ID:         "c1",
+				HostConfig: &containertypes.HostConfig{Resources: tc.resources},
+			}
+			err := verifyHibernate(c)
+			if tc.expected == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.expected)
+			assert.Check(t, errdefs.IsInvalidParameter(err))
+		})
+	}
+}
+
+func TestContainerHibernateErrors(t *testing.T) {
+	d := &Daemon{}
+
+	c := &container.Container{ID: "c1", State: container.NewState()}
+	err := d.containerHibernate(c)
+	assert.Check(t, is.ErrorContains(err, "is not running"))
+	assert.Check(t, errdefs.IsConflict(err))
+
+	c.Running = true
+	c.Paused = true
+	err = d.containerHibernate(c)
+	assert.Check(t, is.ErrorContains(err, "unpause the container before hibernating"))
+	assert.Check(t, errdefs.IsConflict(err))
+
+	c.Hibernated = true
+	err = d.containerHibernate(c)
+	assert.Check(t, is.ErrorContains(err, "is already hibernated"))
+	assert.Check(t, errdefs.IsConflict(err))
+}
+
+func TestContainerResumeNotHibernated(t *testing.T) {
+	d := &Daemon{}
+
+	c := &container.Container{ID: "c1", State: container.NewState()}
+	c.Running = true
+	c.Paused = true
+	err := d.containerResume(c)
+	assert.Check(t, is.ErrorContains(err, "is not hibernated"))
+	assert.Check(t, errdefs.IsConflict(err))
+}
@@ -0,0 +1,21 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+)
+
+func verifyHibernate(c *container.Container) error {
+	return errdefs.NotImplemented(errors.New("hibernating containers is not supported on this platform"))
+}
+
+func (daemon *Daemon) reclaimMemory(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) restoreMemory(c *container.Container) error {
+	return nil
+}
@@ -0,0 +1,248 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/exec"
+	"github.com/sirupsen/logrus"
+)
+
+// hookFailuresFile is the file, in the directory of a container, where the
+// hooks of the container that run on the host record their failures.
+const hookFailuresFile = "hook-failures.json"
+
+// hookPhase holds the hooks of a container that run at a point of its
+// lifecycle.
+type hookPhase struct {
+	name  string
+	hooks []containertypes.Hook
+}
+
+// hookPhases returns the hooks of a container by phase, in the order the
+// phases happen.
+func hookPhases(hooks *containertypes.Hooks) []hookPhase {
+	if hooks == nil {
+		return nil
+	}
+	return []hookPhase{
+		{name: "prestart", hooks: hooks.Prestart},
+		{name: "poststart", hooks: hooks.Poststart},
+		{name: "poststop", hooks: hooks.Poststop},
+	}
+}
+
+// verifyHooks validates the hooks of a container. The hooks that run on the
+// host must be allowed by the daemon configuration.
+func verifyHooks(hooks *containertypes.Hooks, allowed []string) error {
+	for _, phase := range hookPhases(hooks) {
+		for _, h := range phase.hooks {
+			if h.Path == "" {
+				return fmt.Errorf("Invalid %s hook: a path is required", phase.name)
+			}
+			if h.Timeout < 0 {
+				return fmt.Errorf("Invalid %s hook %s: the timeout cannot be negative", phase.name, h.Path)
+			}
+			if h.InContainer {
+				// there is no process to exec into before the container
+				// starts, nor after it stops
+				if phase.name != "poststart" {
+					return fmt.Errorf("Invalid %s hook %s: only poststart hooks can run in the container, %s hooks must run on the host", phase.name, h.Path, phase.name)
+				}
+				continue
+			}
+			if !filepath.IsAbs(h.Path) {
+				return fmt.Errorf("Invalid %s hook %s: the path must be absolute", phase.name, h.Path)
+			}
+			if !hookAllowed(h.Path, allowed) {
+				return fmt.Errorf("The %s hook %s is not allowed. Add it to \"allowed-hooks\" in the daemon configuration to allow it.", phase.name, h.Path)
+			}
+		}
+	}
+	return nil
+}
+
+func hookAllowed(path string, allowed []string) bool {
+	for _, a := range allowed {
+		if filepath.Clean(a) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// hookFailure is the failure of a hook of a container that ran on the host,
+// as recorded by the docker-hook wrapper the hook runs through.
+type hookFailure struct {
+	Phase    string
+	Path     string
+	ExitCode int    `json:",omitempty"`
+	Error    string `json:",omitempty"`
+	Output   string `json:",omitempty"`
+}
+
+func (f *hookFailure) String() string {
+	if f.Error != "" {
+		return fmt.Sprintf("%s hook %s failed: %s", f.Phase, f.Path, f.Error)
+	}
+	return fmt.Sprintf("%s hook %s failed with exit code %d", f.Phase, f.Path, f.ExitCode)
+}
+
+// recordHookFailure appends the failure of a hook to the file at path.
+func recordHookFailure(path string, f hookFailure) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(f); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readHookFailures reads the failures recorded in the file at path, and
+// removes it.
+func readHookFailures(path string) ([]hookFailure, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer os.Remove(path)
+	defer file.Close()
+
+	var failures []hookFailure
+	s := bufio.NewScanner(file)
+	for s.Scan() {
+		var f hookFailure
+		if err := json.Unmarshal(s.Bytes(), &f); err != nil {
+			return failures, err
+		}
+		failures = append(failures, f)
+	}
+	return failures, s.Err()
+}
+
+// logHookFailures emits a "hook_failed" event for each failure of the hooks
+// of a container that ran on the host since they were last read, and returns
+// the first failure of a prestart or poststart hook, which made the
+// container fail to start, if any.
+func (daemon *Daemon) logHookFailures(c *container.Container) *hookFailure {
+	failures, err := readHookFailures(filepath.Join(c.Root, hookFailuresFile))
+	if err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Warn("failed to read the hook failures of container")
+	}
+	var startFailure *hookFailure
+	for i, f := range failures {
+		attributes := map[string]string{
+			"hook": f.Phase,
+			"path": f.Path,
+		}
+		if f.Error != "" {
+			attributes["error"] = f.Error
+		} else {
+			attributes["exitCode"] = strconv.Itoa(f.ExitCode)
+		}
+		logrus.WithFields(logrus.Fields{
+			"container": c.ID,
+			"hook":      f.Path,
+			"exitCode":  f.ExitCode,
+			"output":    f.Output,
+		}).Warnf("%s hook failed", f.Phase)
+		daemon.LogContainerEventWithAttributes(c, "hook_failed", attributes)
+		if startFailure == nil && f.Phase != "poststop" {
+			startFailure = &failures[i]
+		}
+	}
+	return startFailure
+}
+
+// runContainerHooks runs the poststart hooks of a container that run in the
+// container, in order. A failing hook is reported with a "hook_failed" event,
+// and the hooks after it do not run.
+func (daemon *Daemon) runContainerHooks(c *container.Container, hooks []containertypes.Hook) {
+	for _, h := range hooks {
+		if !h.InContainer {
+			continue
+		}
+		exitCode, output, err := daemon.execHook(c, h)
+		if err == nil && exitCode == 0 {
+			continue
+		}
+		attributes := map[string]string{
+			"hook": "poststart",
+			"path": h.Path,
+		}
+		if err != nil {
+			attributes["error"] = err.Error()
+		} else {
+			attributes["exitCode"] = strconv.Itoa(exitCode)
+		}
+		logrus.WithFields(logrus.Fields{
+			"container": c.ID,
+			"hook":      h.Path,
+			"exitCode":  exitCode,
+			"output":    output,
+		}).WithError(err).Warn("poststart hook failed")
+		daemon.LogContainerEventWithAttributes(c, "hook_failed", attributes)
+		return
+	}
+}
+
+// execHook runs a hook in a container, and returns its exit code and output.
+func (daemon *Daemon) execHook(c *container.Container, h containertypes.Hook) (int, string, error) {
+	ctx := context.Background()
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+
+	execConfig := exec.NewConfig()
+	execConfig.OpenStdin = false
+	execConfig.OpenStdout = true
+	execConfig.OpenStderr = true
+	execConfig.ContainerID = c.ID
+	execConfig.DetachKeys = []byte{}
+	execConfig.Entrypoint = h.Path
+	execConfig.Args = h.Args
+	execConfig.Tty = false
+	execConfig.Privileged = false
+	execConfig.User = c.Config.User
+	execConfig.WorkingDir = c.Config.WorkingDir
+
+	linkedEnv, err := daemon.setupLinkedContainers(c)
+	if err != nil {
+		return -1, "", err
+	}
+	execConfig.Env = container.ReplaceOrAppendEnvValues(c.CreateDaemonEnvironment(execConfig.Tty, linkedEnv), h.Env)
+
+	daemon.registerExecCommand(c, execConfig)
+	daemon.LogContainerEventWithAttributes(c, "exec_create: "+execConfig.Entrypoint+" "+strings.Join(execConfig.Args, " "), map[string]string{
+		"execID": execConfig.ID,
+	})
+
+	output := &limitedBuffer{}
+	if err := daemon.ContainerExecStart(ctx, execConfig.ID, nil, output, output); err != nil {
+		return -1, output.String(), err
+	}
+	info, err := daemon.getExecConfig(execConfig.ID)
+	if err != nil {
+		return -1, output.String(), err
+	}
+	if info.ExitCode == nil {
+		return -1, output.String(), fmt.Errorf("hook %s in container %s has no exit code", h.Path, c.ID)
+	}
+	return *info.ExitCode, output.String(), nil
+}
@@ -0,0 +1,90 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/pkg/system"
+)
+
+// hookWrapper is the name the daemon is re-executed under to run the hooks
+// of a container on the host, so that their failures are recorded in the
+// directory of the container rather than only reported in the error text of
+// the runtime, which also ignores the failures of poststop hooks.
+const hookWrapper = "docker-hook"
+
+func init() {
+	reexec.Register(hookWrapper, hookMain)
+}
+
+// hookMain runs a hook with the arguments the runtime passes to the wrapper:
+// the phase of the hook, the file to record its failure in, its timeout in
+// nanoseconds, its path and its arguments. The state of the container is
+// forwarded to the hook on stdin.
+func hookMain() {
+	if len(os.Args) < 5 {
+		fmt.Fprintf(os.Stderr, "usage: %s PHASE FILE TIMEOUT PATH [ARG...]\n", hookWrapper)
+		os.Exit(1)
+	}
+	timeout, err := strconv.ParseInt(os.Args[3], 10, 64)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid hook timeout: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(runHostHook(os.Args[1], os.Args[2], time.Duration(timeout), os.Args[4], os.Args[5:], os.Stdin))
+}
+
+// runHostHook runs a hook on the host, and records its failure in the file
+// at record. It returns the exit code of the wrapper.
+func runHostHook(phase, record string, timeout time.Duration, path string, args []string, stdin io.Reader) int {
+	output := &limitedBuffer{}
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = io.MultiWriter(os.Stdout, output)
+	cmd.Stderr = io.MultiWriter(os.Stderr, output)
+	// run the hook in its own process group, so that the processes it
+	// spawned are killed with it on timeout
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	err := cmd.Start()
+	if err == nil {
+		var timedOut int32
+		if timeout > 0 {
+			timer := time.AfterFunc(timeout, func() {
+				atomic.StoreInt32(&timedOut, 1)
+				syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			})
+			defer timer.Stop()
+		}
+		err = cmd.Wait()
+		if err != nil && atomic.LoadInt32(&timedOut) == 1 {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+	}
+	if err == nil {
+		return 0
+	}
+
+	failure := hookFailure{Phase: phase, Path: path, Output: output.String()}
+	exitCode, exitErr := system.GetExitCode(err)
+	if exitErr != nil || exitCode < 0 {
+		// the hook could not be run, or was killed by a signal
+		failure.Error = err.Error()
+	} else {
+		failure.ExitCode = exitCode
+	}
+	if err := recordHookFailure(record, failure); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to record the failure of %s hook %s: %v\n", phase, path, err)
+	}
+	if exitCode <= 0 {
+		exitCode = 1
+	}
+	return exitCode
+}
@@ -0,0 +1,38 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestRunHostHook(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hook-wrapper")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	record := filepath.Join(dir, hookFailuresFile)
+
+	exitCode := runHostHook("prestart", record, 0, "/bin/sh", []string{"-c", "read state; test \"$state\" = ok"}, strings.NewReader("ok\n"))
+	assert.Check(t, is.Equal(0, exitCode))
+	failures, err := readHookFailures(record)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(failures, 0))
+
+	exitCode = runHostHook("poststop", record, 0, "/bin/sh", []string{"-c", "echo cleanup failed; exit 3"}, strings.NewReader(""))
+	assert.Check(t, is.Equal(3, exitCode))
+	exitCode = runHostHook("prestart", record, 100*time.Millisecond, "/bin/sh", []string{"-c", "sleep 10"}, strings.NewReader(""))
+	assert.Check(t, exitCode != 0)
+	failures, err = readHookFailures(record)
+	assert.NilError(t, err)
+	expected := []hookFailure{
+		{Phase: "poststop", Path: "/bin/sh", ExitCode: 3, Output: "cleanup failed\n"},
+		{Phase: "prestart", Path: "/bin/sh", Error: "timed out after 100ms"},
+	}
+	assert.Check(t, is.DeepEqual(expected, failures))
+}
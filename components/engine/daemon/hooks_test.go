@@ -0,0 +1,65 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVerifyHooks(t *testing.T) {
+	allowed := []string{"/usr/local/bin/register", "/usr/local/bin/cleanup/"}
+
+	assert.NilError(t, verifyHooks(nil, allowed))
+	valid := &containertypes.Hooks{
+		Prestart:  []containertypes.Hook{{Path: "/usr/local/bin/register", Args: []string{"--id", "app"}, Timeout: time.Second}},
+		Poststart: []containertypes.Hook{{Path: "warmup.sh", InContainer: true}},
+		Poststop:  []containertypes.Hook{{Path: "/usr/local/bin/cleanup"}},
+	}
+	assert.NilError(t, verifyHooks(valid, allowed))
+
+	invalids := map[string]*containertypes.Hooks{
+		"Invalid prestart hook: a path is required":                                                                           {Prestart: []containertypes.Hook{{Args: []string{"foo"}}}},
+		"Invalid poststop hook /usr/local/bin/cleanup: the timeout cannot be negative":                                        {Poststop: []containertypes.Hook{{Path: "/usr/local/bin/cleanup", Timeout: -time.Second}}},
+		"Invalid prestart hook warmup.sh: only poststart hooks can run in the container, prestart hooks must run on the host": {Prestart: []containertypes.Hook{{Path: "warmup.sh", InContainer: true}}},
+		"Invalid poststart hook register: the path must be absolute":                                                          {Poststart: []containertypes.Hook{{Path: "register"}}},
+		`The poststop hook /usr/bin/rm is not allowed. Add it to "allowed-hooks" in the daemon configuration to allow it.`:    {Poststop: []containertypes.Hook{{Path: "/usr/bin/rm"}}},
+	}
+	for expected, hooks := range invalids {
+		assert.Check(t, is.Error(verifyHooks(hooks, allowed), expected))
+	}
+}
+
+func TestHookFailures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hook-failures")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, hookFailuresFile)
+
+	failures, err := readHookFailures(path)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(failures, 0))
+
+	recorded := []hookFailure{
+		{Phase: "prestart", Path: "/usr/local/bin/register", ExitCode: 2, Output: "no such app\n"},
+		{Phase: "poststop", Path: "/usr/local/bin/cleanup", Error: "timed out after 1s"},
+	}
+	for _, f := range recorded {
+		assert.NilError(t, recordHookFailure(path, f))
+	}
+	failures, err = readHookFailures(path)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(recorded, failures))
+	assert.Check(t, is.Equal("prestart hook /usr/local/bin/register failed with exit code 2", failures[0].String()))
+	assert.Check(t, is.Equal("poststop hook /usr/local/bin/cleanup failed: timed out after 1s", failures[1].String()))
+
+	// the failures are read once
+	failures, err = readHookFailures(path)
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(failures, 0))
+}
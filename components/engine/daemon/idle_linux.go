@@ -0,0 +1,192 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/sirupsen/logrus"
+)
+
+// minIdleWindow is the shortest window over which the activity of a
+// container can be measured by its idle policy.
+const minIdleWindow = 10 * time.Second
+
+// verifyIdlePolicy validates the idle policy of a container, if it has one.
+func verifyIdlePolicy(policy *containertypes.IdlePolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Window < minIdleWindow {
+		return fmt.Errorf("Idle policy window must be at least %s", minIdleWindow)
+	}
+	if policy.CPUPercent < 0 {
+		return fmt.Errorf("Idle policy CPU threshold can not be less than 0")
+	}
+	if policy.NetworkBytes < 0 {
+		return fmt.Errorf("Idle policy network threshold can not be less than 0")
+	}
+	if policy.CPUPercent == 0 && policy.NetworkBytes == 0 {
+		return fmt.Errorf("Idle policy must set a CPU or network threshold")
+	}
+	if policy.MemoryLimit != 0 && policy.MemoryLimit < linuxMinMemory {
+		return fmt.Errorf("Minimum idle memory limit allowed is 4MB")
+	}
+	return nil
+}
+
+// idleSample holds the activity counters of a container at a point in time.
+type idleSample struct {
+	read         time.Time
+	cpuUsage     uint64
+	networkBytes uint64
+}
+
+func newIdleSample(s types.StatsJSON) idleSample {
+	sample := idleSample{
+		read:     s.Read,
+		cpuUsage: s.CPUStats.CPUUsage.TotalUsage,
+	}
+	for _, n := range s.Networks {
+		sample.networkBytes += n.RxBytes + n.TxBytes
+	}
+	return sample
+}
+
+func counterDelta(first, last uint64) uint64 {
+	if last < first {
+		// the counter was reset, for example because the container restarted
+		return last
+	}
+	return last - first
+}
+
+// isIdle reports whether the activity of a container between two samples
+// stayed below the thresholds of its idle policy. The network threshold is
+// prorated to the time elapsed between the samples.
+func isIdle(policy *containertypes.IdlePolicy, first, last idleSample) bool {
+	elapsed := last.read.Sub(first.read)
+	if elapsed <= 0 {
+		return false
+	}
+	if policy.CPUPercent > 0 {
+		// CPU usage is expressed in nanoseconds
+		percent := float64(counterDelta(first.cpuUsage, last.cpuUsage)) / float64(elapsed) * 100
+		if percent >= policy.CPUPercent {
+			return false
+		}
+	}
+	if policy.NetworkBytes > 0 {
+		threshold := float64(policy.NetworkBytes) * float64(elapsed) / float64(policy.Window)
+		if float64(counterDelta(first.networkBytes, last.networkBytes)) >= threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// idleMemoryLimit returns the memory limit to apply to an idle container. It
+// defaults to the working set of the container, that is its memory usage
+// minus the pages the kernel considers inactive.
+func idleMemoryLimit(policy *containertypes.IdlePolicy, s types.StatsJSON) int64 {
+	if policy.MemoryLimit > 0 {
+		return policy.MemoryLimit
+	}
+	inactive := s.MemoryStats.Stats["inactive_anon"] + s.MemoryStats.Stats["inactive_file"]
+	if s.MemoryStats.Usage <= inactive+linuxMinMemory {
+		return linuxMinMemory
+	}
+	return int64(s.MemoryStats.Usage - inactive)
+}
+
+// watchIdle follows the stats of a running container that has an idle
+// policy. The memory limit of the container is lowered once it has been idle
+// for the window of the policy, and restored as soon as it is active again.
+// It stops when the container is no longer running.
+func (daemon *Daemon) watchIdle(c *container.Container) {
+	policy := c.HostConfig.IdlePolicy
+	if policy == nil {
+		return
+	}
+
+	daemon.watchStats(idleWatcher, c, func() statsHandler {
+		var samples []idleSample
+		return func(s types.StatsJSON) bool {
+			if c.IsPaused() {
+				// paused containers are not active, but not idle either
+				samples = samples[:0]
+				return true
+			}
+
+			sample := newIdleSample(s)
+			samples = append(samples, sample)
+			// keep the oldest sample that still covers the window
+			for len(samples) > 2 && sample.read.Sub(samples[1].read) >= policy.Window {
+				samples = samples[1:]
+			}
+
+			if c.IsIdle() {
+				if len(samples) > 1 && !isIdle(policy, samples[len(samples)-2], sample) {
+					daemon.setContainerActive(c)
+					samples = samples[len(samples)-1:]
+				}
+				return true
+			}
+			if sample.read.Sub(samples[0].read) >= policy.Window && isIdle(policy, samples[0], sample) {
+				if err := daemon.setContainerIdle(c, idleMemoryLimit(policy, s)); err != nil {
+					logrus.WithError(err).WithField("container", c.ID).Warn("failed to lower the memory limit of idle container")
+					// wait for another window before retrying
+					samples = samples[len(samples)-1:]
+				}
+			}
+			return true
+		}
+	}, nil)
+}
+
+// setContainerIdle lowers the memory limit of an idle container, so that
+// the kernel swaps its cold pages out.
+func (daemon *Daemon) setContainerIdle(c *container.Container, limit int64) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.Running || c.Paused || c.Idle {
+		return nil
+	}
+	resources := containerResources(c)
+	if resources.Memory == 0 || limit < resources.Memory {
+		resources.Memory = limit
+		if err := daemon.containerd.UpdateResources(context.Background(), c.ID, toContainerdResources(resources)); err != nil {
+			return err
+		}
+	}
+	c.Idle = true
+	if err := c.CheckpointTo(daemon.containersReplica); err != nil {
+		logrus.WithError(err).Warn("could not save container to disk")
+	}
+	logrus.WithField("container", c.ID).Debugf("container is idle, memory limit lowered to %d", resources.Memory)
+	return nil
+}
+
+// setContainerActive restores the memory limits of a container that is no
+// longer idle.
+func (daemon *Daemon) setContainerActive(c *container.Container) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.Idle {
+		return
+	}
+	if err := daemon.restoreMemory(c); err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Warn("failed to restore the memory limits of active container")
+		return
+	}
+	c.Idle = false
+	if err := c.CheckpointTo(daemon.containersReplica); err != nil {
+		logrus.WithError(err).Warn("could not save container to disk")
+	}
+	logrus.WithField("container", c.ID).Debug("container is active, memory limits restored")
+}
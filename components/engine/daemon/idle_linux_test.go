@@ -0,0 +1,131 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestVerifyIdlePolicy(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		policy   *containertypes.IdlePolicy
+		expected string
+	}{
+		{
+			doc: "no policy",
+		},
+		{
+			doc:    "cpu threshold",
+			policy: &containertypes.IdlePolicy{Window: time.Minute, CPUPercent: 1},
+		},
+		{
+			doc:    "network threshold and memory limit",
+			policy: &containertypes.IdlePolicy{Window: time.Minute, NetworkBytes: 1024, MemoryLimit: 64 * 1024 * 1024},
+		},
+		{
+			doc:      "window too short",
+			policy:   &containertypes.IdlePolicy{Window: time.Second, CPUPercent: 1},
+			expected: "Idle policy window must be at least 10s",
+		},
+		{
+			doc:      "no threshold",
+			policy:   &containertypes.IdlePolicy{Window: time.Minute},
+			expected: "Idle policy must set a CPU or network threshold",
+		},
+		{
+			doc:      "negative cpu threshold",
+			policy:   &containertypes.IdlePolicy{Window: time.Minute, CPUPercent: -1},
+			expected: "Idle policy CPU threshold can not be less than 0",
+		},
+		{
+			doc:      "memory limit too low",
+			policy:   &containertypes.IdlePolicy{Window: time.Minute, CPUPercent: 1, MemoryLimit: 1024},
+			expected: "Minimum idle memory limit allowed is 4MB",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			err := verifyIdlePolicy(tc.policy)
+			if tc.expected == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Error(t, err, tc.expected)
+		})
+	}
+}
+
+func TestIsIdle(t *testing.T) {
+	policy := &containertypes.IdlePolicy{Window: 10 * time.Second, CPUPercent: 5, NetworkBytes: 1000}
+	start := time.Now()
+	first := idleSample{read: start, cpuUsage: 1000, networkBytes: 1000}
+
+	testCases := []struct {
+		doc      string
+		last     idleSample
+		expected bool
+	}{
+		{
+			doc:      "no activity",
+			last:     idleSample{read: start.Add(10 * time.Second), cpuUsage: 1000, networkBytes: 1000},
+			expected: true,
+		},
+		{
+			doc:      "cpu below threshold",
+			last:     idleSample{read: start.Add(10 * time.Second), cpuUsage: 1000 + uint64(400*time.Millisecond), networkBytes: 1000},
+			expected: true,
+		},
+		{
+			doc:  "cpu above threshold",
+			last: idleSample{read: start.Add(10 * time.Second), cpuUsage: 1000 + uint64(600*time.Millisecond), networkBytes: 1000},
+		},
+		{
+			doc:  "network above threshold",
+			last: idleSample{read: start.Add(10 * time.Second), cpuUsage: 1000, networkBytes: 2000},
+		},
+		{
+			doc:  "network above prorated threshold",
+			last: idleSample{read: start.Add(time.Second), cpuUsage: 1000, networkBytes: 1100},
+		},
+		{
+			doc:      "counters reset",
+			last:     idleSample{read: start.Add(10 * time.Second), cpuUsage: 10, networkBytes: 10},
+			expected: true,
+		},
+		{
+			doc:  "no time elapsed",
+			last: first,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			assert.Check(t, is.Equal(tc.expected, isIdle(policy, first, tc.last)))
+		})
+	}
+}
+
+func TestIdleMemoryLimit(t *testing.T) {
+	s := types.StatsJSON{}
+	s.MemoryStats.Usage = 100 * 1024 * 1024
+	s.MemoryStats.Stats = map[string]uint64{
+		"inactive_anon": 30 * 1024 * 1024,
+		"inactive_file": 20 * 1024 * 1024,
+	}
+
+	policy := &containertypes.IdlePolicy{}
+	assert.Check(t, is.Equal(int64(50*1024*1024), idleMemoryLimit(policy, s)))
+
+	policy.MemoryLimit = 16 * 1024 * 1024
+	assert.Check(t, is.Equal(int64(16*1024*1024), idleMemoryLimit(policy, s)))
+
+	s.MemoryStats.Stats["inactive_file"] = 69 * 1024 * 1024
+	policy.MemoryLimit = 0
+	assert.Check(t, is.Equal(int64(linuxMinMemory), idleMemoryLimit(policy, s)))
+}
@@ -0,0 +1,20 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"errors"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+)
+
+func verifyIdlePolicy(policy *containertypes.IdlePolicy) error {
+	if policy != nil {
+		return errors.New("idle policies are not supported on this platform")
+	}
+	return nil
+}
+
+func (daemon *Daemon) watchIdle(c *container.Container) {
+}
@@ -6,10 +6,12 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/pkg/swap"
 	"github.com/docker/docker/pkg/sysinfo"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -19,6 +21,12 @@ import (
 func (daemon *Daemon) fillPlatformInfo(v *types.Info, sysInfo *sysinfo.SysInfo) {
 	v.MemoryLimit = sysInfo.MemoryLimit
 	v.SwapLimit = sysInfo.SwapLimit
+	v.CgroupVersion = "1"
+	if sysInfo.CgroupUnified {
+		v.CgroupVersion = "2"
+	}
+	v.MemorySwapfile = sysInfo.MemorySwapfile
+	v.SwapAreas = daemon.swapAreasInfo()
 	v.KernelMemory = sysInfo.KernelMemory
 	v.OomKillDisable = sysInfo.OomKillDisable
 	v.CPUCfsPeriod = sysInfo.CPUCfsPeriod
@@ -82,10 +90,15 @@ func (daemon *Daemon) fillPlatformInfo(v *types.Info, sysInfo *sysinfo.SysInfo)
 	if !v.SwapLimit {
 		v.Warnings = append(v.Warnings, "WARNING: No swap limit support")
 	}
-	if !v.KernelMemory {
+	// swapfile support is only expected where swap areas are configured
+	if !v.MemorySwapfile && daemon.swapAreasConfigured() {
+		v.Warnings = append(v.Warnings, "WARNING: No swapfile support")
+	}
+	// cgroup v2 has no kernel memory limit nor oom kill disable, by design
+	if !v.KernelMemory && v.CgroupVersion != "2" {
 		v.Warnings = append(v.Warnings, "WARNING: No kernel memory limit support")
 	}
-	if !v.OomKillDisable {
+	if !v.OomKillDisable && v.CgroupVersion != "2" {
 		v.Warnings = append(v.Warnings, "WARNING: No oom kill disable support")
 	}
 	if !v.CPUCfsQuota {
@@ -111,6 +124,48 @@ func (daemon *Daemon) fillPlatformInfo(v *types.Info, sysInfo *sysinfo.SysInfo)
 	}
 }
 
+// swapAreasInfo returns the active swap areas of the host, along with the
+// number of containers assigned to each of them.
+func (daemon *Daemon) swapAreasInfo() []types.SwapAreaInfo {
+	infos := []types.SwapAreaInfo{}
+	areas, err := swap.GetAreas()
+	if err != nil {
+		logrus.Warnf("failed to retrieve swap areas: %v", err)
+		return infos
+	}
+
+	containers, err := daemon.containersReplica.Snapshot().All()
+	if err != nil {
+		logrus.Warnf("failed to retrieve containers: %v", err)
+		return infos
+	}
+	assigned := make(map[string]int)
+	for _, c := range containers {
+		if c.SwapArea != "" {
+			assigned[c.SwapArea]++
+		}
+	}
+
+	for _, a := range areas {
+		infos = append(infos, types.SwapAreaInfo{
+			Path:       a.Path,
+			Type:       a.Type,
+			Size:       a.Size,
+			Used:       a.Used,
+			Priority:   a.Priority,
+			Containers: assigned[filepath.Clean(a.Path)],
+		})
+	}
+	return infos
+}
+
+// swapAreasConfigured returns whether the daemon is configured to assign
+// containers to swap areas, by default or through swap classes.
+func (daemon *Daemon) swapAreasConfigured() bool {
+	conf := daemon.configStore
+	return (conf.MemorySwapfile != "" && conf.MemorySwapfile != swapfileDefault) || len(conf.SwapClasses) > 0
+}
+
 func fillDriverWarnings(v *types.Info) {
 	if v.DriverStatus == nil {
 		return
@@ -3,10 +3,15 @@
 package daemon // import "github.com/docker/docker/daemon"
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/dockerversion"
+	"github.com/docker/docker/pkg/swap"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
 )
@@ -51,3 +56,47 @@ func TestParseInitVersion(t *testing.T) {
 		assert.Check(t, is.DeepEqual(test.result, ver))
 	}
 }
+
+func TestSwapAreasInfo(t *testing.T) {
+	replica, err := container.NewViewDB()
+	assert.NilError(t, err)
+	d := &Daemon{containersReplica: replica}
+
+	// an empty list tells clients that the daemon reports swap areas
+	infos := d.swapAreasInfo()
+	assert.Check(t, infos != nil)
+
+	areas, err := swap.GetAreas()
+	if err != nil || len(areas) == 0 {
+		t.Skip("no active swap area on the host")
+	}
+	c := container.NewBaseContainer("swapper", "")
+	c.HostConfig = &containertypes.HostConfig{}
+	// assigned paths are compared once cleaned
+	swapfile := filepath.Dir(areas[0].Path) + "//" + filepath.Base(areas[0].Path)
+	c.HostConfig.MemorySwapfile = &swapfile
+	assert.NilError(t, d.containersReplica.Save(c))
+
+	infos = d.swapAreasInfo()
+	assert.Assert(t, is.Len(infos, len(areas)))
+	assert.Check(t, is.Equal(areas[0].Path, infos[0].Path))
+	assert.Check(t, is.Equal(1, infos[0].Containers))
+}
+
+func TestSwapAreasConfigured(t *testing.T) {
+	for _, tc := range []struct {
+		swapfile string
+		classes  map[string][]string
+		expected bool
+	}{
+		{},
+		{swapfile: "default"},
+		{swapfile: "/swapfile", expected: true},
+		{classes: map[string][]string{"fast": {"/dev/zram0"}}, expected: true},
+	} {
+		d := &Daemon{configStore: &config.Config{}}
+		d.configStore.MemorySwapfile = tc.swapfile
+		d.configStore.SwapClasses = tc.classes
+		assert.Check(t, is.Equal(tc.expected, d.swapAreasConfigured()), "swapfile %q, classes %v", tc.swapfile, tc.classes)
+	}
+}
@@ -147,18 +147,24 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 	}
 
 	containerState := &types.ContainerState{
-		Status:     container.State.StateString(),
-		Running:    container.State.Running,
-		Paused:     container.State.Paused,
-		Restarting: container.State.Restarting,
-		OOMKilled:  container.State.OOMKilled,
-		Dead:       container.State.Dead,
-		Pid:        container.State.Pid,
-		ExitCode:   container.State.ExitCode(),
-		Error:      container.State.ErrorMsg,
-		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
-		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
-		Health:     containerHealth,
+		Status:      container.State.StateString(),
+		Running:     container.State.Running,
+		Paused:      container.State.Paused,
+		Hibernated:  container.State.Hibernated,
+		Idle:        container.State.Idle,
+		Restarting:  container.State.Restarting,
+		OOMKilled:   container.State.OOMKilled,
+		Dead:        container.State.Dead,
+		Pid:         container.State.Pid,
+		ExitCode:    container.State.ExitCode(),
+		Error:       container.State.ErrorMsg,
+		StartedAt:   container.State.StartedAt.Format(time.RFC3339Nano),
+		FinishedAt:  container.State.FinishedAt.Format(time.RFC3339Nano),
+		Health:      containerHealth,
+		SwapWarning: container.State.SwapWarning,
+
+		MemoryRecommendation: container.State.MemoryRecommendation,
+		OOMReport:            container.State.OOMReport,
 	}
 
 	contJSONBase := &types.ContainerJSONBase{
@@ -169,6 +175,7 @@ func (daemon *Daemon) getInspectData(container *container.Container) (*types.Con
 		State:        containerState,
 		Image:        container.ImageID.String(),
 		LogPath:      container.LogPath,
+		SwapArea:     container.SwapArea(),
 		Name:         container.Name,
 		RestartCount: container.RestartCount,
 		Driver:       container.Driver,
@@ -106,6 +106,11 @@ func (daemon *Daemon) killWithSignal(container *containerpkg.Container, sig int)
 	}
 
 	if unpause {
+		if container.Hibernated {
+			if err := daemon.restoreMemory(container); err != nil {
+				logrus.Warnf("Cannot restore memory limits of container %s: %s", container.ID, err)
+			}
+		}
 		// above kill signal will be sent once resume is finished
 		if err := daemon.containerd.Resume(context.Background(), container.ID); err != nil {
 			logrus.Warnf("Cannot unpause container %s: %s", container.ID, err)
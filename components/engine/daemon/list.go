@@ -33,6 +33,7 @@ var acceptedPsFilterTags = map[string]bool{
 	"is-task":   true,
 	"publish":   true,
 	"expose":    true,
+	"idle":      true,
 }
 
 // iterationAction represents possible outcomes happening during the container iteration.
@@ -85,6 +86,11 @@ type listContext struct {
 	// isTask tells us if the we should filter container that are a task (true) or not (false)
 	isTask bool
 
+	// idleFilter tells if we should filter based on whether a container is idle
+	idleFilter bool
+	// isIdle tells us if we should filter containers that are idle (true) or not (false)
+	isIdle bool
+
 	// publish is a list of published ports to filter with
 	publish map[nat.Port]bool
 	// expose is a list of exposed ports to filter with
@@ -283,6 +289,19 @@ func (daemon *Daemon) foldFilter(view container.View, config *types.ContainerLis
 		}
 	}
 
+	var idleFilter, isIdle bool
+	if psFilters.Contains("idle") {
+		if psFilters.ExactMatch("idle", "true") {
+			idleFilter = true
+			isIdle = true
+		} else if psFilters.ExactMatch("idle", "false") {
+			idleFilter = true
+			isIdle = false
+		} else {
+			return nil, invalidFilter{"idle", psFilters.Get("idle")}
+		}
+	}
+
 	err = psFilters.WalkValues("health", func(value string) error {
 		if !container.IsValidHealthString(value) {
 			return errdefs.InvalidParameter(errors.Errorf("Unrecognised filter value for health: %s", value))
@@ -353,6 +372,8 @@ func (daemon *Daemon) foldFilter(view container.View, config *types.ContainerLis
 		sinceFilter:          sinceContFilter,
 		taskFilter:           taskFilter,
 		isTask:               isTask,
+		idleFilter:           idleFilter,
+		isIdle:               isIdle,
 		publish:              publishFilter,
 		expose:               exposeFilter,
 		ContainerListOptions: config,
@@ -445,6 +466,12 @@ func includeContainerInList(container *container.Snapshot, ctx *listContext) ite
 		}
 	}
 
+	if ctx.idleFilter {
+		if ctx.isIdle != container.Idle {
+			return excludeContainer
+		}
+	}
+
 	// Do not include container if any of the labels don't match
 	if !ctx.filters.MatchKVList("label", container.Labels) {
 		return excludeContainer
@@ -143,3 +143,35 @@ func TestNameFilter(t *testing.T) {
 	assert.Assert(t, is.Len(containerListWithPrefix, 1))
 	assert.Assert(t, containerListContainsName(containerListWithPrefix, three.Name))
 }
+
+func TestIdleFilter(t *testing.T) {
+	db, err := container.NewViewDB()
+	assert.Assert(t, err == nil)
+	d := &Daemon{
+		containersReplica: db,
+	}
+
+	active := setupContainerWithName(t, "active", d)
+	idle := setupContainerWithName(t, "idle", d)
+	idle.Idle = true
+	d.containersReplica.Save(idle)
+
+	containerList, err := d.Containers(&types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("idle", "true")),
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(containerList, 1))
+	assert.Assert(t, containerListContainsName(containerList, idle.Name))
+
+	containerList, err = d.Containers(&types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("idle", "false")),
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(containerList, 1))
+	assert.Assert(t, containerListContainsName(containerList, active.Name))
+
+	_, err = d.Containers(&types.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("idle", "maybe")),
+	})
+	assert.Assert(t, is.ErrorContains(err, "Invalid filter 'idle=[maybe]'"))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/docker/go-metrics"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
@@ -58,3 +59,35 @@ func registerMetricsPluginCallback(store *plugin.Store, sockPath string) {
 		}
 	})
 }
+
+// swapAreaMetrics exports the size and usage of the swap areas of the host,
+// along with the number of containers assigned to each of them.
+type swapAreaMetrics struct {
+	daemon     *Daemon
+	size       *prometheus.Desc
+	used       *prometheus.Desc
+	containers *prometheus.Desc
+}
+
+func (m *swapAreaMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.size
+	ch <- m.used
+	ch <- m.containers
+}
+
+func (m *swapAreaMetrics) Collect(ch chan<- prometheus.Metric) {
+	for _, a := range m.daemon.swapAreasInfo() {
+		ch <- prometheus.MustNewConstMetric(m.size, prometheus.GaugeValue, float64(a.Size), a.Path, a.Type)
+		ch <- prometheus.MustNewConstMetric(m.used, prometheus.GaugeValue, float64(a.Used), a.Path, a.Type)
+		ch <- prometheus.MustNewConstMetric(m.containers, prometheus.GaugeValue, float64(a.Containers), a.Path, a.Type)
+	}
+}
+
+func (daemon *Daemon) addPlatformMetrics(ns *metrics.Namespace) {
+	ns.Add(&swapAreaMetrics{
+		daemon:     daemon,
+		size:       ns.NewDesc("swap_area_size", "The size of the swap area", metrics.Bytes, "path", "type"),
+		used:       ns.NewDesc("swap_area_used", "The used space of the swap area", metrics.Bytes, "path", "type"),
+		containers: ns.NewDesc("swap_area_containers", "The number of containers assigned to the swap area", "", "path", "type"),
+	})
+}
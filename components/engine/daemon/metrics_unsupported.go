@@ -2,7 +2,10 @@
 
 package daemon // import "github.com/docker/docker/daemon"
 
-import "github.com/docker/docker/pkg/plugingetter"
+import (
+	"github.com/docker/docker/pkg/plugingetter"
+	"github.com/docker/go-metrics"
+)
 
 func registerMetricsPluginCallback(getter plugingetter.PluginGetter, sockPath string) {
 }
@@ -10,3 +13,6 @@ func registerMetricsPluginCallback(getter plugingetter.PluginGetter, sockPath st
 func (daemon *Daemon) listenMetricsSock() (string, error) {
 	return "", nil
 }
+
+func (daemon *Daemon) addPlatformMetrics(ns *metrics.Namespace) {
+}
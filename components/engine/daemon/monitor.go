@@ -40,14 +40,18 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			return errors.New("received StateOOM from libcontainerd on Windows. This should never happen")
 		}
 
+		// reading the stats locks the container, so take the snapshot first
+		report := daemon.oomReport(c)
+
 		c.Lock()
 		defer c.Unlock()
+		c.OOMReport = report
 		daemon.updateHealthMonitor(c)
 		if err := c.CheckpointTo(daemon.containersReplica); err != nil {
 			return err
 		}
 
-		daemon.LogContainerEvent(c, "oom")
+		daemon.LogContainerEventWithAttributes(c, "oom", oomEventAttributes(report))
 	case libcontainerd.EventExit:
 		if int(ei.Pid) == c.Pid {
 			c.Lock()
@@ -155,6 +159,10 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 			daemon.setStateCounter(c)
 
 			daemon.initHealthMonitor(c)
+			daemon.watchSwapPressure(c)
+			daemon.watchIdle(c)
+			daemon.watchMemoryAutoscale(c)
+			daemon.watchContainerMetrics(c)
 
 			if err := c.CheckpointTo(daemon.containersReplica); err != nil {
 				return err
@@ -181,6 +189,7 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerd.EventType, ei libc
 
 		if c.Paused {
 			c.Paused = false
+			c.Hibernated = false
 			daemon.setStateCounter(c)
 			daemon.updateHealthMonitor(c)
 
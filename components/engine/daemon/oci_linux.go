@@ -9,11 +9,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	daemonconfig "github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/oci"
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
 	volumemounts "github.com/docker/docker/volume/mounts"
@@ -715,29 +717,39 @@ func (daemon *Daemon) createSpec(c *container.Container) (retSpec *specs.Spec, e
 	if err := setResources(&s, c.HostConfig.Resources); err != nil {
 		return nil, fmt.Errorf("linux runtime spec resources: %v", err)
 	}
+	if swapfile := c.SwapArea(); swapfile != "" {
+		s.Linux.Resources.Memory.Swapfile = &swapfile
+	}
+	if cgroup2.IsEnabled() {
+		s.Linux.Resources.Unified = cgroup2.Resources(s.Linux.Resources)
+	}
 	s.Linux.Sysctl = c.HostConfig.Sysctls
 
-	p := s.Linux.CgroupsPath
-	if useSystemd {
-		initPath, err := cgroups.GetInitCgroup("cpu")
-		if err != nil {
-			return nil, err
-		}
-		_, err = cgroups.GetOwnCgroup("cpu")
-		if err != nil {
-			return nil, err
+	// The real-time scheduling settings of the parent cgroups only exist
+	// in cgroup v1.
+	if !cgroup2.IsEnabled() {
+		p := s.Linux.CgroupsPath
+		if useSystemd {
+			initPath, err := cgroups.GetInitCgroup("cpu")
+			if err != nil {
+				return nil, err
+			}
+			_, err = cgroups.GetOwnCgroup("cpu")
+			if err != nil {
+				return nil, err
+			}
+			p = filepath.Join(initPath, s.Linux.CgroupsPath)
 		}
-		p = filepath.Join(initPath, s.Linux.CgroupsPath)
-	}
 
-	// Clean path to guard against things like ../../../BAD
-	parentPath := filepath.Dir(p)
-	if !filepath.IsAbs(parentPath) {
-		parentPath = filepath.Clean("/" + parentPath)
-	}
+		// Clean path to guard against things like ../../../BAD
+		parentPath := filepath.Dir(p)
+		if !filepath.IsAbs(parentPath) {
+			parentPath = filepath.Clean("/" + parentPath)
+		}
 
-	if err := daemon.initCgroupsPath(parentPath); err != nil {
-		return nil, fmt.Errorf("linux init cgroups path: %v", err)
+		if err := daemon.initCgroupsPath(parentPath); err != nil {
+			return nil, fmt.Errorf("linux init cgroups path: %v", err)
+		}
 	}
 	if err := setDevices(&s, c); err != nil {
 		return nil, fmt.Errorf("linux runtime spec devices: %v", err)
@@ -813,6 +825,9 @@ func (daemon *Daemon) createSpec(c *container.Container) (retSpec *specs.Spec, e
 			}
 		}
 	}
+	if err := setHooks(daemon, &s, c); err != nil {
+		return nil, fmt.Errorf("linux spec hooks: %v", err)
+	}
 
 	if apparmor.IsEnabled() {
 		var appArmorProfile string
@@ -854,6 +869,52 @@ func (daemon *Daemon) createSpec(c *container.Container) (retSpec *specs.Spec, e
 	return &s, nil
 }
 
+// setHooks adds the hooks of a container that run on the host to its spec,
+// after the hooks of the daemon.
+func setHooks(daemon *Daemon, s *specs.Spec, c *container.Container) error {
+	hooks := c.HostConfig.Hooks
+	if hooks == nil {
+		return nil
+	}
+	// the allowed hooks may have changed since the container was created
+	if err := verifyHooks(hooks, daemon.configStore.AllowedHooks); err != nil {
+		return err
+	}
+	if s.Hooks == nil {
+		s.Hooks = &specs.Hooks{}
+	}
+	s.Hooks.Prestart = append(s.Hooks.Prestart, hostHooks(c, "prestart", hooks.Prestart)...)
+	s.Hooks.Poststart = append(s.Hooks.Poststart, hostHooks(c, "poststart", hooks.Poststart)...)
+	s.Hooks.Poststop = append(s.Hooks.Poststop, hostHooks(c, "poststop", hooks.Poststop)...)
+	return nil
+}
+
+// hostHooks converts the hooks of a phase that run on the host to runtime
+// hooks running them through the hook wrapper.
+func hostHooks(c *container.Container, phase string, hooks []containertypes.Hook) []specs.Hook {
+	var specHooks []specs.Hook
+	wrapper := filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe")
+	record := filepath.Join(c.Root, hookFailuresFile)
+	for _, h := range hooks {
+		if h.InContainer {
+			continue
+		}
+		hook := specs.Hook{
+			Path: wrapper,
+			Args: append([]string{hookWrapper, phase, record, strconv.FormatInt(int64(h.Timeout), 10), h.Path}, h.Args...),
+			Env:  h.Env,
+		}
+		if h.Timeout > 0 {
+			// the runtime takes the timeout in seconds; leave the wrapper
+			// the time to record the failure of the hook
+			timeout := int((h.Timeout+time.Second-1)/time.Second) + 1
+			hook.Timeout = &timeout
+		}
+		specHooks = append(specHooks, hook)
+	}
+	return specHooks
+}
+
 func clearReadOnly(m *specs.Mount) {
 	var opt []string
 	for _, o := range m.Options {
@@ -2,13 +2,17 @@ package daemon // import "github.com/docker/docker/daemon"
 
 import (
 	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	containertypes "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/oci"
 	"github.com/docker/docker/pkg/idtools"
+	"github.com/opencontainers/runtime-spec/specs-go"
 	"gotest.tools/assert"
 	is "gotest.tools/assert/cmp"
 )
@@ -100,3 +104,51 @@ func TestGetSourceMount(t *testing.T) {
 	_, _, err = getSourceMount(cwd)
 	assert.NilError(t, err)
 }
+
+// TestSetHooks checks that the hooks of a container that run on the host are
+// added to its spec after the hooks of the daemon, and that the hooks that are
+// no longer allowed are rejected.
+func TestSetHooks(t *testing.T) {
+	d := Daemon{
+		configStore: &config.Config{},
+	}
+	d.configStore.AllowedHooks = []string{"/usr/local/bin/register"}
+	c := &container.Container{
+		Root: "/var/lib/docker/containers/app",
+		HostConfig: &containertypes.HostConfig{
+			Hooks: &containertypes.Hooks{
+				Prestart: []containertypes.Hook{{
+					Path:    "/usr/local/bin/register",
+					Args:    []string{"--id", "app"},
+					Env:     []string{"FOO=bar"},
+					Timeout: 1500 * time.Millisecond,
+				}},
+				Poststart: []containertypes.Hook{{Path: "warmup.sh", InContainer: true}},
+			},
+		},
+	}
+
+	s := oci.DefaultSpec()
+	s.Hooks = &specs.Hooks{
+		Prestart: []specs.Hook{{Path: "/proc/self/exe"}},
+	}
+	assert.NilError(t, setHooks(&d, &s, c))
+
+	timeout := 3
+	expected := &specs.Hooks{
+		Prestart: []specs.Hook{
+			{Path: "/proc/self/exe"},
+			{
+				Path:    filepath.Join("/proc", strconv.Itoa(os.Getpid()), "exe"),
+				Args:    []string{"docker-hook", "prestart", "/var/lib/docker/containers/app/hook-failures.json", "1500000000", "/usr/local/bin/register", "--id", "app"},
+				Env:     []string{"FOO=bar"},
+				Timeout: &timeout,
+			},
+		},
+	}
+	assert.Check(t, is.DeepEqual(expected, s.Hooks))
+
+	d.configStore.AllowedHooks = nil
+	err := setHooks(&d, &s, c)
+	assert.Check(t, is.ErrorContains(err, "is not allowed"))
+}
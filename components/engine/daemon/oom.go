@@ -0,0 +1,29 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+)
+
+// oomEventAttributes returns the attributes of the "oom" event of a
+// container, summarizing its OOM report.
+func oomEventAttributes(r *types.OOMReport) map[string]string {
+	attributes := map[string]string{}
+	if r == nil {
+		return attributes
+	}
+	attributes["memoryUsage"] = strconv.FormatUint(r.MemoryStats.Usage, 10)
+	attributes["memoryLimit"] = strconv.FormatUint(r.MemoryStats.Limit, 10)
+	attributes["swapUsage"] = strconv.FormatUint(r.SwapStats.Usage, 10)
+	if r.SwapStats.Swapfile != "" {
+		attributes["swapfile"] = r.SwapStats.Swapfile
+	}
+	if len(r.Processes) > 0 {
+		p := r.Processes[0]
+		attributes["topProcess"] = p.Command
+		attributes["topProcessPid"] = strconv.Itoa(p.PID)
+		attributes["topProcessRSS"] = strconv.FormatUint(p.RSS, 10)
+	}
+	return attributes
+}
@@ -0,0 +1,101 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/sirupsen/logrus"
+)
+
+// maxOOMProcesses is the number of processes listed in an OOM report.
+const maxOOMProcesses = 10
+
+// oomReport takes a snapshot of the memory usage of a container that ran out
+// of memory. It is best effort: the parts of the snapshot that cannot be read,
+// for example because the container exited in the meantime, are left empty.
+func (daemon *Daemon) oomReport(c *container.Container) *types.OOMReport {
+	r := &types.OOMReport{Time: time.Now().UTC()}
+
+	if s, err := daemon.stats(c); err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Debug("failed to read the memory stats of the OOM killed container")
+	} else {
+		r.MemoryStats = s.MemoryStats
+		r.SwapStats = s.SwapStats
+	}
+
+	pids, err := daemon.containerd.ListPids(context.Background(), c.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("container", c.ID).Debug("failed to list the processes of the OOM killed container")
+		return r
+	}
+	for _, pid := range pids {
+		b, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+		if err != nil {
+			// the process exited
+			continue
+		}
+		p, err := parseProcStatus(string(b))
+		if err != nil {
+			continue
+		}
+		p.PID = int(pid)
+		r.Processes = append(r.Processes, p)
+	}
+	r.Processes = topOOMProcesses(r.Processes)
+	return r
+}
+
+// topOOMProcesses returns the processes with the largest RSS, largest first.
+func topOOMProcesses(processes []types.OOMProcess) []types.OOMProcess {
+	sort.SliceStable(processes, func(i, j int) bool {
+		return processes[i].RSS > processes[j].RSS
+	})
+	if len(processes) > maxOOMProcesses {
+		processes = processes[:maxOOMProcesses]
+	}
+	return processes
+}
+
+// parseProcStatus reads the command and the resident set size of a process
+// from its /proc/<pid>/status file.
+func parseProcStatus(content string) (types.OOMProcess, error) {
+	/*
+	   Name:	java
+	   ...
+	   VmRSS:	  524288 kB
+	*/
+	var p types.OOMProcess
+	s := bufio.NewScanner(strings.NewReader(content))
+	for s.Scan() {
+		kv := strings.SplitN(s.Text(), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		switch kv[0] {
+		case "Name":
+			p.Command = value
+		case "VmRSS":
+			rss, err := strconv.ParseUint(strings.TrimSuffix(value, " kB"), 10, 64)
+			if err != nil {
+				return p, fmt.Errorf("invalid VmRSS value %q", value)
+			}
+			p.RSS = rss * 1024
+		}
+	}
+	if err := s.Err(); err != nil {
+		return p, err
+	}
+	if p.Command == "" {
+		return p, fmt.Errorf("process status has no name")
+	}
+	return p, nil
+}
@@ -0,0 +1,37 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestParseProcStatus(t *testing.T) {
+	p, err := parseProcStatus("Name:\tjava\nUmask:\t0022\nState:\tS (sleeping)\nVmRSS:\t  524288 kB\nThreads:\t12\n")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(types.OOMProcess{Command: "java", RSS: 512 * 1024 * 1024}, p))
+
+	// kernel threads have no memory of their own
+	p, err = parseProcStatus("Name:\tkworker/0:1\nState:\tI (idle)\n")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(types.OOMProcess{Command: "kworker/0:1"}, p))
+
+	_, err = parseProcStatus("Name:\tjava\nVmRSS:\tlots\n")
+	assert.Check(t, is.ErrorContains(err, "invalid VmRSS value"))
+
+	_, err = parseProcStatus("")
+	assert.Check(t, is.ErrorContains(err, "process status has no name"))
+}
+
+func TestTopOOMProcesses(t *testing.T) {
+	var processes []types.OOMProcess
+	for i := 1; i <= maxOOMProcesses+2; i++ {
+		processes = append(processes, types.OOMProcess{PID: i, RSS: uint64(i)})
+	}
+	top := topOOMProcesses(processes)
+	assert.Assert(t, is.Len(top, maxOOMProcesses))
+	assert.Check(t, is.Equal(maxOOMProcesses+2, top[0].PID))
+	assert.Check(t, is.Equal(3, top[maxOOMProcesses-1].PID))
+}
@@ -0,0 +1,31 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestOOMEventAttributes(t *testing.T) {
+	assert.Check(t, is.Len(oomEventAttributes(nil), 0))
+
+	r := &types.OOMReport{
+		MemoryStats: types.MemoryStats{Usage: 512, Limit: 512},
+		SwapStats:   types.SwapStats{Usage: 128, Swapfile: "/swap/web"},
+		Processes: []types.OOMProcess{
+			{PID: 42, Command: "java", RSS: 400},
+			{PID: 1, Command: "sh", RSS: 4},
+		},
+	}
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"memoryUsage":   "512",
+		"memoryLimit":   "512",
+		"swapUsage":     "128",
+		"swapfile":      "/swap/web",
+		"topProcess":    "java",
+		"topProcessPid": "42",
+		"topProcessRSS": "400",
+	}, oomEventAttributes(r)))
+}
@@ -0,0 +1,12 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+func (daemon *Daemon) oomReport(c *container.Container) *types.OOMReport {
+	return nil
+}
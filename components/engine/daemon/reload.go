@@ -22,6 +22,9 @@ import (
 // - Insecure registries
 // - Registry mirrors
 // - Daemon live restore
+// - Default container swapfile, swappiness and memory+swap ratio
+// - Container swap pressure events threshold
+// - Swap classes
 func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	daemon.configStore.Lock()
 	attributes := map[string]string{}
@@ -38,6 +38,37 @@ func (daemon *Daemon) reloadPlatform(conf *config.Config, attributes map[string]
 		daemon.configStore.IpcMode = conf.IpcMode
 	}
 
+	if conf.IsValueSet("default-memory-swapfile") {
+		daemon.configStore.MemorySwapfile = conf.MemorySwapfile
+	}
+
+	if conf.IsValueSet("default-memory-swappiness") && conf.MemorySwappiness != nil {
+		daemon.configStore.MemorySwappiness = conf.MemorySwappiness
+	}
+
+	if conf.IsValueSet("default-memory-swap-ratio") {
+		daemon.configStore.MemorySwapRatio = conf.MemorySwapRatio
+	}
+
+	if conf.IsValueSet("memory-swap-alert") {
+		daemon.configStore.MemorySwapAlert = conf.MemorySwapAlert
+		// watch the containers that were started while swap pressure
+		// events were disabled.
+		for _, c := range daemon.containers.List() {
+			if c.IsRunning() && !daemon.statsWatchers.watching(swapPressureWatcher, c.ID) {
+				daemon.watchSwapPressure(c)
+			}
+		}
+	}
+
+	if conf.IsValueSet("swap-classes") {
+		daemon.configStore.SwapClasses = conf.SwapClasses
+	}
+
+	if conf.IsValueSet("allowed-hooks") {
+		daemon.configStore.AllowedHooks = conf.AllowedHooks
+	}
+
 	// Update attributes
 	var runtimeList bytes.Buffer
 	for name, rt := range daemon.configStore.Runtimes {
@@ -51,6 +82,14 @@ func (daemon *Daemon) reloadPlatform(conf *config.Config, attributes map[string]
 	attributes["default-runtime"] = daemon.configStore.DefaultRuntime
 	attributes["default-shm-size"] = fmt.Sprintf("%d", daemon.configStore.ShmSize)
 	attributes["default-ipc-mode"] = daemon.configStore.IpcMode
+	attributes["default-memory-swapfile"] = daemon.configStore.MemorySwapfile
+	if daemon.configStore.MemorySwappiness != nil {
+		attributes["default-memory-swappiness"] = fmt.Sprintf("%d", *daemon.configStore.MemorySwappiness)
+	}
+	attributes["default-memory-swap-ratio"] = fmt.Sprintf("%v", daemon.configStore.MemorySwapRatio)
+	attributes["memory-swap-alert"] = daemon.configStore.MemorySwapAlert
+	attributes["swap-classes"] = fmt.Sprintf("%v", daemon.configStore.SwapClasses)
+	attributes["allowed-hooks"] = fmt.Sprintf("%v", daemon.configStore.AllowedHooks)
 
 	return nil
 }
@@ -0,0 +1,79 @@
+// +build linux freebsd
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestDaemonReloadMemorySwapDefaults(t *testing.T) {
+	daemon := &Daemon{
+		configStore: &config.Config{},
+	}
+
+	swappiness := int64(10)
+	newConfig := &config.Config{
+		CommonConfig: config.CommonConfig{
+			ValuesSet: map[string]interface{}{
+				"default-memory-swapfile":   "/swap/nvme0",
+				"default-memory-swappiness": 10,
+				"default-memory-swap-ratio": 1.5,
+			},
+		},
+		MemorySwapfile:   "/swap/nvme0",
+		MemorySwappiness: &swappiness,
+		MemorySwapRatio:  1.5,
+	}
+
+	attributes := map[string]string{}
+	assert.NilError(t, daemon.reloadPlatform(newConfig, attributes))
+	assert.Check(t, is.Equal("/swap/nvme0", attributes["default-memory-swapfile"]))
+	assert.Check(t, is.Equal("10", attributes["default-memory-swappiness"]))
+	assert.Check(t, is.Equal("1.5", attributes["default-memory-swap-ratio"]))
+
+	// new containers pick up the reloaded defaults
+	hostConfig := &containertypes.HostConfig{
+		Resources: containertypes.Resources{Memory: 100 * 1024 * 1024},
+	}
+	daemon.setDefaultSwapfile(hostConfig)
+	assert.NilError(t, daemon.adaptContainerSettings(hostConfig, false))
+	assert.Check(t, is.Equal(int64(150*1024*1024), hostConfig.MemorySwap))
+	assert.Assert(t, hostConfig.MemorySwappiness != nil)
+	assert.Check(t, is.Equal(int64(10), *hostConfig.MemorySwappiness))
+	assert.Assert(t, hostConfig.MemorySwapfile != nil)
+	assert.Check(t, is.Equal("/swap/nvme0", *hostConfig.MemorySwapfile))
+
+	newConfig.MemorySwapRatio = 0.5
+	assert.Check(t, is.ErrorContains(daemon.reloadPlatform(newConfig, attributes), "Default memory swap ratio setting"))
+}
+
+func TestDaemonReloadMemorySwapAlert(t *testing.T) {
+	daemon := &Daemon{
+		configStore:   &config.Config{},
+		containers:    container.NewMemoryStore(),
+		statsWatchers: newStatsWatchers(),
+	}
+
+	newConfig := &config.Config{
+		CommonConfig: config.CommonConfig{
+			ValuesSet: map[string]interface{}{
+				"memory-swap-alert": "80%",
+			},
+		},
+		MemorySwapAlert: "80%",
+	}
+
+	attributes := map[string]string{}
+	assert.NilError(t, daemon.reloadPlatform(newConfig, attributes))
+	assert.Check(t, is.Equal("80%", attributes["memory-swap-alert"]))
+	assert.Check(t, is.Equal(float64(80), daemon.swapAlertThreshold()))
+
+	newConfig.MemorySwapAlert = "0"
+	assert.Check(t, is.ErrorContains(daemon.reloadPlatform(newConfig, attributes), "Memory swap alert setting (0) is invalid"))
+}
@@ -92,6 +92,9 @@ func (daemon *Daemon) ContainerStart(name string, hostConfig *containertypes.Hos
 			return errdefs.InvalidParameter(err)
 		}
 	}
+	if err := daemon.startDependencies(container); err != nil {
+		return err
+	}
 	return daemon.containerStart(container, checkpoint, checkpointDir, true)
 }
 
@@ -151,6 +154,14 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 		return err
 	}
 
+	if err := daemon.setupSwapfile(container); err != nil {
+		return errdefs.System(err)
+	}
+
+	if err := daemon.setupSwapClass(container); err != nil {
+		return err
+	}
+
 	spec, err := daemon.createSpec(container)
 	if err != nil {
 		return errdefs.System(err)
@@ -203,6 +214,9 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 			logrus.WithError(err).WithField("container", container.ID).
 				Error("failed to delete failed start container")
 		}
+		if f := daemon.logHookFailures(container); f != nil {
+			return errdefs.System(errors.New(f.String()))
+		}
 		return translateContainerdStartErr(container.Path, container.SetExitCode, err)
 	}
 
@@ -212,6 +226,10 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 	daemon.setStateCounter(container)
 
 	daemon.initHealthMonitor(container)
+	daemon.watchSwapPressure(container)
+	daemon.watchIdle(container)
+	daemon.watchMemoryAutoscale(container)
+	daemon.watchContainerMetrics(container)
 
 	if err := container.CheckpointTo(daemon.containersReplica); err != nil {
 		logrus.WithError(err).WithField("container", container.ID).
@@ -221,6 +239,10 @@ func (daemon *Daemon) containerStart(container *container.Container, checkpoint
 	daemon.LogContainerEvent(container, "start")
 	containerActions.WithValues("start").UpdateSince(start)
 
+	if hooks := container.HostConfig.Hooks; hooks != nil {
+		go daemon.runContainerHooks(container, hooks.Poststart)
+	}
+
 	return nil
 }
 
@@ -264,4 +286,7 @@ func (daemon *Daemon) Cleanup(container *container.Container) {
 	if err := daemon.containerd.Delete(context.Background(), container.ID); err != nil {
 		logrus.Errorf("%s cleanup: failed to delete container from containerd: %v", container.ID, err)
 	}
+
+	// the poststop hooks ran when the task was deleted
+	daemon.logHookFailures(container)
 }
@@ -0,0 +1,283 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// dependencyTimeout is the longest time to wait for a dependency of a
+	// container to become healthy or to complete.
+	dependencyTimeout = 5 * time.Minute
+	// dependencyPollInterval is the interval at which the health status of
+	// a dependency is checked.
+	dependencyPollInterval = 100 * time.Millisecond
+)
+
+// dependency is a container another container depends on, along with the
+// condition it must reach.
+type dependency struct {
+	*container.Container
+	condition containertypes.DependencyCondition
+}
+
+// verifyDependencies validates the dependencies of a container. The
+// dependencies must exist when the container is created, and are resolved to
+// their IDs, so that renaming a dependency, or creating another container
+// with the name of a removed one, does not change them.
+func (daemon *Daemon) verifyDependencies(hostConfig *containertypes.HostConfig, update bool) error {
+	if update {
+		return nil
+	}
+	for i, d := range hostConfig.DependsOn {
+		if d.Container == "" {
+			return errors.New("invalid dependency: a container name is required")
+		}
+		switch d.Condition {
+		case "", containertypes.DependencyStarted, containertypes.DependencyHealthy, containertypes.DependencyCompleted:
+		default:
+			return errors.Errorf("invalid condition %q for dependency %s: must be one of started, healthy or completed", d.Condition, d.Container)
+		}
+		dep, err := daemon.GetContainer(d.Container)
+		if err != nil {
+			return errors.Errorf("invalid dependency %s: no such container", d.Container)
+		}
+		if d.Container != dep.ID {
+			hostConfig.DependsOn[i].Container = dep.ID
+		}
+	}
+	return nil
+}
+
+// dependencies returns the containers c depends on, in the order they are
+// set in its host config.
+func (daemon *Daemon) dependencies(c *container.Container) ([]dependency, error) {
+	if c.HostConfig == nil {
+		return nil, nil
+	}
+	var deps []dependency
+	for _, d := range c.HostConfig.DependsOn {
+		dep, err := daemon.GetContainer(d.Container)
+		if err != nil {
+			return nil, errdefs.Conflict(errors.Errorf("dependency %s of container %s: no such container", d.Container, containerName(c)))
+		}
+		condition := d.Condition
+		if condition == "" {
+			condition = containertypes.DependencyStarted
+		}
+		deps = append(deps, dependency{Container: dep, condition: condition})
+	}
+	return deps, nil
+}
+
+// checkDependencyCycle returns an error if c depends on itself, directly or
+// through its dependencies. Dependencies that no longer exist are ignored.
+func (daemon *Daemon) checkDependencyCycle(c *container.Container) error {
+	return daemon.walkDependencies(c, nil, make(map[string]bool))
+}
+
+func (daemon *Daemon) walkDependencies(c *container.Container, path []*container.Container, acyclic map[string]bool) error {
+	for i, p := range path {
+		if p.ID == c.ID {
+			var names []string
+			for _, p := range append(path[i:], c) {
+				names = append(names, containerName(p))
+			}
+			return errdefs.Conflict(errors.Errorf("dependency cycle between containers: %s", strings.Join(names, " -> ")))
+		}
+	}
+	if acyclic[c.ID] || c.HostConfig == nil {
+		return nil
+	}
+	path = append(path, c)
+	for _, d := range c.HostConfig.DependsOn {
+		dep, err := daemon.GetContainer(d.Container)
+		if err != nil {
+			continue
+		}
+		if err := daemon.walkDependencies(dep, path, acyclic); err != nil {
+			return err
+		}
+	}
+	acyclic[c.ID] = true
+	return nil
+}
+
+// startDependencies starts the dependencies of a container that are not
+// running, and waits for each of them to reach its condition.
+func (daemon *Daemon) startDependencies(c *container.Container) error {
+	if c.HostConfig == nil || len(c.HostConfig.DependsOn) == 0 {
+		return nil
+	}
+	if err := daemon.checkDependencyCycle(c); err != nil {
+		return err
+	}
+	deps, err := daemon.dependencies(c)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		if !dep.IsRunning() {
+			if err := daemon.ContainerStart(dep.ID, nil, "", ""); err != nil {
+				if _, ok := err.(containerNotModifiedError); !ok {
+					return errors.Wrapf(err, "failed to start dependency %s of container %s", containerName(dep.Container), containerName(c))
+				}
+			}
+		}
+		if err := daemon.waitForDependency(c, dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForDependency waits for a dependency of a container to reach its
+// condition. It fails if the dependency stops before, or if it does not reach
+// its condition within dependencyTimeout.
+func (daemon *Daemon) waitForDependency(c *container.Container, dep dependency) error {
+	name := containerName(dep.Container)
+	switch dep.condition {
+	case containertypes.DependencyHealthy:
+		if getProbe(dep.Container) == nil {
+			return errdefs.Conflict(errors.Errorf("dependency %s of container %s has no healthcheck", name, containerName(c)))
+		}
+		ticker := time.NewTicker(dependencyPollInterval)
+		defer ticker.Stop()
+		timeout := time.After(dependencyTimeout)
+		for {
+			if !dep.IsRunning() {
+				return errdefs.Conflict(errors.Errorf("dependency %s of container %s stopped before becoming healthy", name, containerName(c)))
+			}
+			dep.Lock()
+			h := dep.State.Health
+			dep.Unlock()
+			if h != nil {
+				switch h.Status() {
+				case types.Healthy:
+					return nil
+				case types.Unhealthy:
+					return errdefs.Conflict(errors.Errorf("dependency %s of container %s is unhealthy", name, containerName(c)))
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-timeout:
+				return errdefs.Conflict(errors.Errorf("timed out waiting for dependency %s of container %s to become healthy", name, containerName(c)))
+			}
+		}
+	case containertypes.DependencyCompleted:
+		ctx, cancel := context.WithTimeout(context.Background(), dependencyTimeout)
+		defer cancel()
+		status := <-dep.Wait(ctx, container.WaitConditionNotRunning)
+		if status.Err() != nil {
+			return errdefs.Conflict(errors.Errorf("timed out waiting for dependency %s of container %s to complete", name, containerName(c)))
+		}
+		if status.ExitCode() != 0 {
+			return errdefs.Conflict(errors.Errorf("dependency %s of container %s exited with code %d", name, containerName(c), status.ExitCode()))
+		}
+		return nil
+	default:
+		if !dep.IsRunning() {
+			return errdefs.Conflict(errors.Errorf("dependency %s of container %s is not running", name, containerName(c)))
+		}
+		return nil
+	}
+}
+
+// restartDependent restarts a container that depends on other containers when
+// the daemon starts, once its dependencies reached their condition. It runs in
+// the background of the restore of the daemon, and closes notify once done.
+func (daemon *Daemon) restartDependent(c *container.Container, notify chan struct{}, restartContainers map[*container.Container]chan struct{}) {
+	defer close(notify)
+
+	if err := daemon.waitForRestoredDependencies(c, restartContainers); err != nil {
+		logrus.Errorf("Failed to start container %s: %s", c.ID, err)
+		return
+	}
+
+	// Make sure networks are available before starting
+	daemon.waitForNetworks(c)
+	if err := daemon.containerStart(c, "", "", true); err != nil {
+		logrus.Errorf("Failed to start container %s: %s", c.ID, err)
+	}
+}
+
+// waitForRestoredDependencies waits for the dependencies of a container that
+// is restarted when the daemon starts to reach their condition. Dependencies
+// that are restarted as well are waited for to be started first.
+func (daemon *Daemon) waitForRestoredDependencies(c *container.Container, restartContainers map[*container.Container]chan struct{}) error {
+	if c.HostConfig == nil || len(c.HostConfig.DependsOn) == 0 {
+		return nil
+	}
+	if err := daemon.checkDependencyCycle(c); err != nil {
+		return err
+	}
+	deps, err := daemon.dependencies(c)
+	if err != nil {
+		return err
+	}
+	for _, dep := range deps {
+		if notifier, exists := restartContainers[dep.Container]; exists {
+			select {
+			case <-notifier:
+			case <-time.After(dependencyTimeout):
+				return errdefs.Conflict(errors.Errorf("timed out waiting for dependency %s of container %s to be restarted", containerName(dep.Container), containerName(c)))
+			}
+		}
+		if err := daemon.waitForDependency(c, dep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependents returns the containers that depend on c.
+func (daemon *Daemon) dependents(c *container.Container) []*container.Container {
+	var dependents []*container.Container
+	for _, other := range daemon.List() {
+		if other.ID == c.ID || other.HostConfig == nil {
+			continue
+		}
+		for _, d := range other.HostConfig.DependsOn {
+			if dep, err := daemon.GetContainer(d.Container); err == nil && dep.ID == c.ID {
+				dependents = append(dependents, other)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// shutdownDependents returns, for each container, the containers that depend
+// on it, and that are stopped before it on shutdown. The dependencies of
+// containers that are part of, or lead to, a dependency cycle are left out,
+// so that the shutdown does not wait on them forever.
+func (daemon *Daemon) shutdownDependents(containers []*container.Container) map[string][]*container.Container {
+	dependents := make(map[string][]*container.Container)
+	for _, c := range containers {
+		if c.HostConfig == nil || len(c.HostConfig.DependsOn) == 0 {
+			continue
+		}
+		if daemon.checkDependencyCycle(c) != nil {
+			continue
+		}
+		for _, d := range c.HostConfig.DependsOn {
+			if dep, err := daemon.GetContainer(d.Container); err == nil {
+				dependents[dep.ID] = append(dependents[dep.ID], c)
+			}
+		}
+	}
+	return dependents
+}
+
+func containerName(c *container.Container) string {
+	return strings.TrimPrefix(c.Name, "/")
+}
@@ -0,0 +1,222 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/truncindex"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func newDependencyTestDaemon(t *testing.T, containers ...*container.Container) *Daemon {
+	store := container.NewMemoryStore()
+	index := truncindex.NewTruncIndex([]string{})
+	replica, err := container.NewViewDB()
+	assert.NilError(t, err)
+	daemon := &Daemon{
+		containers:        store,
+		containersReplica: replica,
+		idIndex:           index,
+	}
+	for _, c := range containers {
+		if c.State == nil {
+			c.State = container.NewState()
+		}
+		store.Add(c.ID, c)
+		assert.NilError(t, index.Add(c.ID))
+		_, err := daemon.reserveName(c.ID, c.Name)
+		assert.NilError(t, err)
+	}
+	return daemon
+}
+
+func dependentContainer(id string, deps ...string) *container.Container {
+	c := &container.Container{
+		ID:         id,
+		Name:       "/" + id,
+		HostConfig: &containertypes.HostConfig{},
+	}
+	for _, d := range deps {
+		c.HostConfig.DependsOn = append(c.HostConfig.DependsOn, containertypes.Dependency{Container: d})
+	}
+	return c
+}
+
+func TestVerifyDependencies(t *testing.T) {
+	daemon := newDependencyTestDaemon(t, dependentContainer("db"))
+
+	valid := &containertypes.HostConfig{
+		DependsOn: []containertypes.Dependency{
+			{Container: "db"},
+			{Container: "db", Condition: containertypes.DependencyHealthy},
+		},
+	}
+	assert.NilError(t, daemon.verifyDependencies(valid, false))
+
+	invalids := map[string]containertypes.Dependency{
+		"invalid dependency: a container name is required":                                          {Condition: containertypes.DependencyStarted},
+		`invalid condition "ready" for dependency db: must be one of started, healthy or completed`: {Container: "db", Condition: "ready"},
+		"invalid dependency cache: no such container":                                               {Container: "cache"},
+	}
+	for expected, d := range invalids {
+		hostConfig := &containertypes.HostConfig{DependsOn: []containertypes.Dependency{d}}
+		assert.Check(t, is.Error(daemon.verifyDependencies(hostConfig, false), expected))
+	}
+}
+
+func TestCheckDependencyCycle(t *testing.T) {
+	daemon := newDependencyTestDaemon(t,
+		dependentContainer("app", "db", "cache"),
+		dependentContainer("db"),
+		dependentContainer("cache", "db"),
+		dependentContainer("one", "two"),
+		dependentContainer("two", "three"),
+		dependentContainer("three", "one"),
+		dependentContainer("four", "one"),
+		dependentContainer("self", "self"),
+		dependentContainer("orphan", "removed"),
+	)
+
+	for _, name := range []string{"app", "db", "cache", "orphan"} {
+		c, err := daemon.GetContainer(name)
+		assert.NilError(t, err)
+		assert.Check(t, daemon.checkDependencyCycle(c), name)
+	}
+
+	cycles := map[string]string{
+		"one":  "dependency cycle between containers: one -> two -> three -> one",
+		"four": "dependency cycle between containers: one -> two -> three -> one",
+		"self": "dependency cycle between containers: self -> self",
+	}
+	for name, expected := range cycles {
+		c, err := daemon.GetContainer(name)
+		assert.NilError(t, err)
+		assert.Check(t, is.Error(daemon.checkDependencyCycle(c), expected), name)
+	}
+}
+
+func TestShutdownDependents(t *testing.T) {
+	app := dependentContainer("app", "db", "cache")
+	db := dependentContainer("db")
+	cache := dependentContainer("cache", "db")
+	one := dependentContainer("one", "two")
+	two := dependentContainer("two", "one")
+	daemon := newDependencyTestDaemon(t, app, db, cache, one, two)
+
+	dependents := make(map[string][]string)
+	for id, containers := range daemon.shutdownDependents([]*container.Container{app, db, cache, one, two}) {
+		for _, c := range containers {
+			dependents[id] = append(dependents[id], c.ID)
+		}
+	}
+	// containers in a cycle are stopped without waiting
+	expected := map[string][]string{
+		"db":    {"app", "cache"},
+		"cache": {"app"},
+	}
+	assert.Check(t, is.DeepEqual(expected, dependents))
+}
+
+func TestWaitForDependency(t *testing.T) {
+	app := dependentContainer("app")
+	job := dependentContainer("job")
+	daemon := newDependencyTestDaemon(t, app, job)
+
+	job.SetStopped(&container.ExitStatus{ExitCode: 0})
+	assert.NilError(t, daemon.waitForDependency(app, dependency{Container: job, condition: containertypes.DependencyCompleted}))
+
+	job.SetStopped(&container.ExitStatus{ExitCode: 3})
+	err := daemon.waitForDependency(app, dependency{Container: job, condition: containertypes.DependencyCompleted})
+	assert.Check(t, is.Error(err, "dependency job of container app exited with code 3"))
+
+	err = daemon.waitForDependency(app, dependency{Container: job, condition: containertypes.DependencyStarted})
+	assert.Check(t, is.Error(err, "dependency job of container app is not running"))
+
+	job.Config = &containertypes.Config{}
+	err = daemon.waitForDependency(app, dependency{Container: job, condition: containertypes.DependencyHealthy})
+	assert.Check(t, is.Error(err, "dependency job of container app has no healthcheck"))
+}
+
+func TestWaitForRestoredDependencies(t *testing.T) {
+	app := dependentContainer("app", "db")
+	db := dependentContainer("db")
+	daemon := newDependencyTestDaemon(t, app, db)
+
+	notifier := make(chan struct{})
+	restartContainers := map[*container.Container]chan struct{}{db: notifier}
+	done := make(chan error)
+	go func() {
+		done <- daemon.waitForRestoredDependencies(app, restartContainers)
+	}()
+
+	// the dependency is waited for until it was restarted
+	select {
+	case err := <-done:
+		t.Fatalf("dependency not waited for: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	db.SetRunning(1234, true)
+	close(notifier)
+	assert.Check(t, <-done)
+}
+
+// TestDependenciesResolvedToIDs checks that the dependencies of a container
+// follow a renamed dependency, and not a new container with its former name.
+func TestDependenciesResolvedToIDs(t *testing.T) {
+	db := &container.Container{
+		ID:         "9a1f3c6e2b7d",
+		Name:       "/db",
+		HostConfig: &containertypes.HostConfig{},
+	}
+	daemon := newDependencyTestDaemon(t, db)
+
+	hostConfig := &containertypes.HostConfig{
+		DependsOn: []containertypes.Dependency{{Container: "db", Condition: containertypes.DependencyHealthy}},
+	}
+	assert.NilError(t, daemon.verifyDependencies(hostConfig, false))
+	expected := []containertypes.Dependency{{Container: db.ID, Condition: containertypes.DependencyHealthy}}
+	assert.Check(t, is.DeepEqual(expected, hostConfig.DependsOn))
+
+	app := dependentContainer("app")
+	app.HostConfig = hostConfig
+	other := dependentContainer("other")
+	daemon = newDependencyTestDaemon(t, db, app, other)
+
+	daemon.releaseName(db.Name)
+	db.Name = "/postgres"
+	_, err := daemon.reserveName(db.ID, db.Name)
+	assert.NilError(t, err)
+	_, err = daemon.reserveName(other.ID, "/db")
+	assert.NilError(t, err)
+
+	deps, err := daemon.dependencies(app)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(deps, 1))
+	assert.Check(t, is.Equal(db.ID, deps[0].ID))
+}
+
+func TestContainerRmDependency(t *testing.T) {
+	app := dependentContainer("app", "db")
+	cache := dependentContainer("cache", "db")
+	db := dependentContainer("db")
+	daemon := newDependencyTestDaemon(t, app, cache, db)
+
+	var names []string
+	for _, c := range daemon.dependents(db) {
+		names = append(names, c.ID)
+	}
+	sort.Strings(names)
+	assert.Check(t, is.DeepEqual([]string{"app", "cache"}, names))
+	assert.Check(t, is.Len(daemon.dependents(app), 0))
+
+	err := daemon.ContainerRm("db", &types.ContainerRmConfig{})
+	assert.Check(t, errdefs.IsConflict(err))
+	assert.Check(t, is.ErrorContains(err, "You cannot remove container db, which containers"))
+	assert.Check(t, daemon.containers.Get("db") != nil)
+}
@@ -0,0 +1,123 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// Features following the stats of the running containers.
+const (
+	swapPressureWatcher = "swap-pressure"
+	idleWatcher         = "idle"
+	autoscaleWatcher    = "autoscale"
+	metricsWatcher      = "metrics"
+)
+
+// statsHandler is called with each stats sample of a running container. The
+// watcher stops if it returns false.
+type statsHandler func(types.StatsJSON) bool
+
+type statsWatcherKey struct {
+	feature   string
+	container string
+}
+
+type statsWatcher struct {
+	stop chan struct{}
+}
+
+// statsWatchers tracks the goroutines following the stats of the running
+// containers on behalf of the features of the daemon, one per feature and
+// container.
+type statsWatchers struct {
+	mu       sync.Mutex
+	watchers map[statsWatcherKey]*statsWatcher
+}
+
+func newStatsWatchers() *statsWatchers {
+	return &statsWatchers{watchers: make(map[statsWatcherKey]*statsWatcher)}
+}
+
+// add registers a new watcher for the feature and container, and stops the
+// one it replaces, if any.
+func (w *statsWatchers) add(key statsWatcherKey) *statsWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if old, exists := w.watchers[key]; exists {
+		close(old.stop)
+	}
+	sw := &statsWatcher{stop: make(chan struct{})}
+	w.watchers[key] = sw
+	return sw
+}
+
+// remove unregisters a watcher, unless it was replaced already.
+func (w *statsWatchers) remove(key statsWatcherKey, sw *statsWatcher) {
+	w.mu.Lock()
+	if w.watchers[key] == sw {
+		delete(w.watchers, key)
+	}
+	w.mu.Unlock()
+}
+
+// watching returns true if the stats of a container are followed on behalf
+// of the feature.
+func (w *statsWatchers) watching(feature, id string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, exists := w.watchers[statsWatcherKey{feature: feature, container: id}]
+	return exists
+}
+
+// watchStats follows the stats of a running container on behalf of a
+// feature, calling the handler returned by newHandler with each sample. It is
+// called on each start of the container: the watcher of the previous start,
+// if any, is stopped, and newHandler is called again so that the new watcher
+// does not inherit its state. The watcher stops when the container is no
+// longer running, or when the handler returns false; stopped, if set, is
+// called then.
+func (daemon *Daemon) watchStats(feature string, c *container.Container, newHandler func() statsHandler, stopped func()) {
+	key := statsWatcherKey{feature: feature, container: c.ID}
+	sw := daemon.statsWatchers.add(key)
+	handle := newHandler()
+
+	ch := daemon.subscribeToContainerStats(c)
+	go func() {
+		defer func() {
+			daemon.statsWatchers.remove(key, sw)
+			daemon.unsubscribeToContainerStats(c, ch)
+			if stopped != nil {
+				stopped()
+			}
+		}()
+
+		for {
+			select {
+			case <-sw.stop:
+				return
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case <-sw.stop:
+					// the container was started again since the sample
+					return
+				default:
+				}
+				if !c.IsRunning() {
+					return
+				}
+				s, ok := v.(types.StatsJSON)
+				if !ok {
+					continue
+				}
+				if !handle(s) {
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,36 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestStatsWatchersReplace(t *testing.T) {
+	w := newStatsWatchers()
+	key := statsWatcherKey{feature: idleWatcher, container: "abcdef"}
+
+	first := w.add(key)
+	assert.Check(t, w.watching(idleWatcher, "abcdef"))
+	assert.Check(t, !w.watching(autoscaleWatcher, "abcdef"))
+
+	// a new start of the container stops the watcher of the previous one
+	second := w.add(key)
+	select {
+	case <-first.stop:
+	default:
+		t.Fatal("expected the replaced watcher to be stopped")
+	}
+	select {
+	case <-second.stop:
+		t.Fatal("expected the new watcher to keep running")
+	default:
+	}
+
+	// the replaced watcher exiting does not unregister the new one
+	w.remove(key, first)
+	assert.Check(t, w.watching(idleWatcher, "abcdef"))
+
+	w.remove(key, second)
+	assert.Check(t, !w.watching(idleWatcher, "abcdef"))
+}
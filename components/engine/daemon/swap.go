@@ -0,0 +1,84 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	swapservice "github.com/docker/docker/daemon/swap"
+	"github.com/docker/docker/pkg/swap"
+)
+
+// swapAreasTTL is how long the swap areas read from /proc/swaps are reused.
+// Stats are sampled every second for every container and subscriber, so the
+// areas are read at most once per collector tick.
+const swapAreasTTL = time.Second
+
+// swapAreaCache caches the active swap areas of the host.
+type swapAreaCache struct {
+	mu      sync.Mutex
+	areas   []swap.Area
+	err     error
+	updated time.Time
+}
+
+// get returns the active swap areas of the host, reading them again if the
+// cached ones are older than swapAreasTTL.
+func (c *swapAreaCache) get() ([]swap.Area, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.updated.IsZero() || time.Since(c.updated) >= swapAreasTTL {
+		c.areas, c.err = swap.GetAreas()
+		c.updated = time.Now()
+	}
+	return c.areas, c.err
+}
+
+// swapHost gives access to the swap areas of the host.
+type swapHost interface {
+	IsActive(path string) (bool, error)
+	On(path string, priority int) error
+	GetAreas() ([]swap.Area, error)
+}
+
+// hostSwap implements swapHost with the swap areas of the host.
+type hostSwap struct{}
+
+func (hostSwap) IsActive(path string) (bool, error) { return swap.IsActive(path) }
+
+func (hostSwap) On(path string, priority int) error { return swap.On(path, priority) }
+
+func (hostSwap) GetAreas() ([]swap.Area, error) { return swap.GetAreas() }
+
+// swapCgroupReader reads the swap area applied to the memory cgroup of a
+// process.
+type swapCgroupReader interface {
+	Swapfile(pid int) (string, error)
+}
+
+// hostCgroups implements swapCgroupReader with the cgroups of the host.
+type hostCgroups struct{}
+
+// SwapService returns the service managing the swap areas of the host.
+func (daemon *Daemon) SwapService() *swapservice.Service {
+	return daemon.swaps
+}
+
+// swapReferences returns the IDs of the containers assigned to the swap
+// area backed by path.
+func (daemon *Daemon) swapReferences(path string) ([]string, error) {
+	containers, err := daemon.containersReplica.Snapshot().All()
+	if err != nil {
+		return nil, err
+	}
+	path = filepath.Clean(path)
+	var ids []string
+	for _, c := range containers {
+		if c.SwapArea == path {
+			ids = append(ids, c.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
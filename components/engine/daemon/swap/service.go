@@ -0,0 +1,435 @@
+package swap // import "github.com/docker/docker/daemon/swap"
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stringid"
+	pkgswap "github.com/docker/docker/pkg/swap"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const stateFile = "areas.json"
+
+var (
+	acceptedListFilters = map[string]bool{
+		"name":     true,
+		"label":    true,
+		"dangling": true,
+	}
+	acceptedPruneFilters = map[string]bool{
+		"label":  true,
+		"label!": true,
+	}
+)
+
+// ReferenceFunc returns the IDs of the containers assigned to the swap area
+// backed by path.
+type ReferenceFunc func(path string) ([]string, error)
+
+// swapper is the interface to the kernel's swap areas.
+type swapper interface {
+	GetAreas() ([]pkgswap.Area, error)
+	Create(path string, size int64) error
+	On(path string, priority int) error
+	Off(path string) error
+}
+
+type hostSwapper struct{}
+
+func (hostSwapper) GetAreas() ([]pkgswap.Area, error)    { return pkgswap.GetAreas() }
+func (hostSwapper) Create(path string, size int64) error { return pkgswap.Create(path, size) }
+func (hostSwapper) On(path string, priority int) error   { return pkgswap.On(path, priority) }
+func (hostSwapper) Off(path string) error                { return pkgswap.Off(path) }
+
+// areaConfig is the persisted configuration of a swap area.
+type areaConfig struct {
+	Name      string
+	Path      string
+	Priority  int
+	Managed   bool
+	CreatedAt time.Time
+	Labels    map[string]string
+}
+
+// Service manages the swap areas known to the daemon.
+type Service struct {
+	mu           sync.Mutex
+	root         string
+	areas        map[string]*areaConfig
+	refs         ReferenceFunc
+	swapper      swapper
+	pruneRunning int32
+}
+
+// NewService creates a swap service storing its state under root. Swap areas
+// that were created previously but are no longer active (for example after
+// a reboot of the host) are activated again.
+func NewService(root string, refs ReferenceFunc) (*Service, error) {
+	return newService(root, refs, hostSwapper{})
+}
+
+func newService(root string, refs ReferenceFunc, sw swapper) (*Service, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	s := &Service{
+		root:    root,
+		areas:   make(map[string]*areaConfig),
+		refs:    refs,
+		swapper: sw,
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.restore()
+	return s, nil
+}
+
+func (s *Service) load() error {
+	b, err := ioutil.ReadFile(filepath.Join(s.root, stateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var configs []*areaConfig
+	if err := json.Unmarshal(b, &configs); err != nil {
+		return errors.Wrap(err, "error loading swap areas")
+	}
+	for _, c := range configs {
+		s.areas[c.Name] = c
+	}
+	return nil
+}
+
+// save persists the swap areas. Callers must hold s.mu.
+func (s *Service) save() error {
+	configs := make([]*areaConfig, 0, len(s.areas))
+	for _, c := range s.areas {
+		configs = append(configs, c)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].Name < configs[j].Name })
+	b, err := json.Marshal(configs)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(s.root, stateFile), b, 0600)
+}
+
+func (s *Service) restore() {
+	active, err := s.activeAreas()
+	if err != nil {
+		logrus.WithError(err).Warn("failed to read active swap areas")
+		return
+	}
+	for _, c := range s.areas {
+		if _, ok := active[c.Path]; ok {
+			continue
+		}
+		if err := s.swapper.On(c.Path, c.Priority); err != nil {
+			logrus.WithError(err).WithField("swap", c.Name).Warn("failed to activate swap area")
+		}
+	}
+}
+
+func (s *Service) activeAreas() (map[string]pkgswap.Area, error) {
+	areas, err := s.swapper.GetAreas()
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[string]pkgswap.Area, len(areas))
+	for _, a := range areas {
+		active[a.Path] = a
+	}
+	return active, nil
+}
+
+// Create registers a swap area and activates it. If a size is given, the
+// swap file is allocated and formatted first, and is deleted again when the
+// area is removed.
+func (s *Service) Create(ctx context.Context, opts swaptypes.CreateOptions) (*swaptypes.Area, error) {
+	if opts.Path == "" || !filepath.IsAbs(opts.Path) {
+		return nil, errdefs.InvalidParameter(errors.New("swap area path must be an absolute path"))
+	}
+	if opts.Size < 0 {
+		return nil, errdefs.InvalidParameter(errors.New("swap area size cannot be negative"))
+	}
+	path := filepath.Clean(opts.Path)
+	name := opts.Name
+	if name == "" {
+		name = stringid.GenerateRandomID()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.areas[name]; exists {
+		return nil, errdefs.Conflict(errors.Errorf("swap area %s already exists", name))
+	}
+	for _, c := range s.areas {
+		if c.Path == path {
+			return nil, errdefs.Conflict(errors.Errorf("%s is already used by swap area %s", path, c.Name))
+		}
+	}
+
+	priority := -1
+	if opts.Priority != nil {
+		priority = *opts.Priority
+	}
+	c := &areaConfig{
+		Name:      name,
+		Path:      path,
+		Priority:  priority,
+		CreatedAt: time.Now().UTC(),
+		Labels:    opts.Labels,
+	}
+	if opts.Size > 0 {
+		if _, err := os.Stat(path); err == nil {
+			return nil, errdefs.InvalidParameter(errors.Errorf("cannot create swap file %s: file already exists", path))
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, errdefs.System(err)
+		}
+		if err := s.swapper.Create(path, opts.Size); err != nil {
+			return nil, errdefs.System(err)
+		}
+		c.Managed = true
+	} else if _, err := os.Stat(path); err != nil {
+		return nil, errdefs.InvalidParameter(errors.Wrap(err, "invalid swap area"))
+	}
+
+	active, err := s.activeAreas()
+	if err != nil {
+		return nil, errdefs.System(err)
+	}
+	if _, ok := active[path]; !ok {
+		if err := s.swapper.On(path, c.Priority); err != nil {
+			if c.Managed {
+				os.Remove(path)
+			}
+			return nil, errdefs.System(errors.Wrap(err, "failed to activate swap area"))
+		}
+	}
+
+	s.areas[name] = c
+	if err := s.save(); err != nil {
+		delete(s.areas, name)
+		return nil, errdefs.System(err)
+	}
+	if active, err = s.activeAreas(); err != nil {
+		logrus.WithError(err).Debug("failed to read active swap areas")
+	}
+	return s.toAPI(c, active)
+}
+
+// Get returns the swap area with the given name.
+func (s *Service) Get(ctx context.Context, name string) (*swaptypes.Area, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.areas[name]
+	if !ok {
+		return nil, errdefs.NotFound(errors.Errorf("no such swap area: %s", name))
+	}
+	active, err := s.activeAreas()
+	if err != nil {
+		logrus.WithError(err).Debug("failed to read active swap areas")
+	}
+	return s.toAPI(c, active)
+}
+
+// List returns the swap areas matching the given filters.
+func (s *Service) List(ctx context.Context, filter filters.Args) ([]*swaptypes.Area, error) {
+	if err := filter.Validate(acceptedListFilters); err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	var dangling, danglingOnly bool
+	if filter.Contains("dangling") {
+		if filter.ExactMatch("dangling", "true") || filter.ExactMatch("dangling", "1") {
+			dangling, danglingOnly = true, true
+		} else if filter.ExactMatch("dangling", "false") || filter.ExactMatch("dangling", "0") {
+			danglingOnly = true
+		} else {
+			return nil, errdefs.InvalidParameter(errors.Errorf("invalid filter 'dangling=%s'", filter.Get("dangling")))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active, err := s.activeAreas()
+	if err != nil {
+		logrus.WithError(err).Debug("failed to read active swap areas")
+	}
+	out := []*swaptypes.Area{}
+	for _, c := range s.areas {
+		if filter.Contains("name") && !filter.Match("name", c.Name) {
+			continue
+		}
+		if !filter.MatchKVList("label", c.Labels) {
+			continue
+		}
+		a, err := s.toAPI(c, active)
+		if err != nil {
+			return nil, err
+		}
+		if danglingOnly && (len(a.Containers) == 0) != dangling {
+			continue
+		}
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// Remove deactivates and removes a swap area. Areas that are still assigned
+// to a container cannot be removed.
+func (s *Service) Remove(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.areas[name]
+	if !ok {
+		return errdefs.NotFound(errors.Errorf("no such swap area: %s", name))
+	}
+	_, err := s.remove(c)
+	return err
+}
+
+// remove deactivates and removes a swap area, and returns the number of
+// bytes freed on disk. Callers must hold s.mu.
+func (s *Service) remove(c *areaConfig) (int64, error) {
+	refs, err := s.refs(c.Path)
+	if err != nil {
+		return 0, errdefs.System(err)
+	}
+	if len(refs) > 0 {
+		return 0, errdefs.Conflict(errors.Errorf("swap area %s is in use by container(s) %s", c.Name, strings.Join(refs, ", ")))
+	}
+	active, err := s.activeAreas()
+	if err != nil {
+		return 0, errdefs.System(err)
+	}
+	if _, ok := active[c.Path]; ok {
+		if err := s.swapper.Off(c.Path); err != nil {
+			return 0, errdefs.System(errors.Wrap(err, "failed to deactivate swap area"))
+		}
+	}
+	var freed int64
+	if c.Managed {
+		if fi, err := os.Stat(c.Path); err == nil {
+			freed = fi.Size()
+		}
+		if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+			return 0, errdefs.System(err)
+		}
+	}
+	delete(s.areas, c.Name)
+	if err := s.save(); err != nil {
+		return freed, errdefs.System(err)
+	}
+	return freed, nil
+}
+
+// Prune removes the swap areas matching the given filters that are not
+// assigned to any container.
+func (s *Service) Prune(ctx context.Context, pruneFilters filters.Args) (*types.SwapsPruneReport, error) {
+	if !atomic.CompareAndSwapInt32(&s.pruneRunning, 0, 1) {
+		return nil, errdefs.Conflict(errors.New("a prune operation is already running"))
+	}
+	defer atomic.StoreInt32(&s.pruneRunning, 0)
+
+	if err := pruneFilters.Validate(acceptedPruneFilters); err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rep := &types.SwapsPruneReport{}
+	for _, c := range s.areas {
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			if err == context.Canceled {
+				err = nil
+			}
+			return rep, err
+		default:
+		}
+
+		if !pruneFilters.MatchKVList("label", c.Labels) {
+			continue
+		}
+		if pruneFilters.Contains("label!") && pruneFilters.MatchKVList("label!", c.Labels) {
+			continue
+		}
+		freed, err := s.remove(c)
+		if errdefs.IsConflict(err) {
+			// the area is assigned to a container
+			continue
+		}
+		if err != nil {
+			logrus.WithError(err).WithField("swap", c.Name).Warn("could not remove swap area")
+			continue
+		}
+		rep.SpaceReclaimed += uint64(freed)
+		rep.SwapsDeleted = append(rep.SwapsDeleted, c.Name)
+	}
+	return rep, nil
+}
+
+func (s *Service) toAPI(c *areaConfig, active map[string]pkgswap.Area) (*swaptypes.Area, error) {
+	refs, err := s.refs(c.Path)
+	if err != nil {
+		return nil, errdefs.System(err)
+	}
+	a := &swaptypes.Area{
+		Name:       c.Name,
+		Path:       c.Path,
+		Priority:   c.Priority,
+		Managed:    c.Managed,
+		CreatedAt:  c.CreatedAt.Format(time.RFC3339),
+		Labels:     c.Labels,
+		Containers: refs,
+	}
+	if a.Labels == nil {
+		a.Labels = map[string]string{}
+	}
+	if a.Containers == nil {
+		a.Containers = []string{}
+	}
+	if info, ok := active[c.Path]; ok {
+		a.Active = true
+		a.Type = info.Type
+		a.Size = info.Size
+		a.Used = info.Used
+		a.Priority = info.Priority
+		return a, nil
+	}
+	if fi, err := os.Stat(c.Path); err == nil {
+		if fi.Mode()&os.ModeDevice != 0 {
+			a.Type = "partition"
+		} else {
+			a.Type = "file"
+			a.Size = fi.Size()
+		}
+	}
+	return a, nil
+}
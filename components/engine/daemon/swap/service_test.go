@@ -0,0 +1,181 @@
+package swap // import "github.com/docker/docker/daemon/swap"
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types/filters"
+	swaptypes "github.com/docker/docker/api/types/swap"
+	"github.com/docker/docker/errdefs"
+	pkgswap "github.com/docker/docker/pkg/swap"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+type fakeSwapper struct {
+	active map[string]pkgswap.Area
+}
+
+func (f *fakeSwapper) GetAreas() ([]pkgswap.Area, error) {
+	var out []pkgswap.Area
+	for _, a := range f.active {
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func (f *fakeSwapper) Create(path string, size int64) error {
+	return ioutil.WriteFile(path, make([]byte, size), 0600)
+}
+
+func (f *fakeSwapper) On(path string, priority int) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	f.active[path] = pkgswap.Area{Path: path, Type: "file", Size: fi.Size(), Priority: priority}
+	return nil
+}
+
+func (f *fakeSwapper) Off(path string) error {
+	delete(f.active, path)
+	return nil
+}
+
+func newTestService(t *testing.T, refs map[string][]string) (*Service, *fakeSwapper, string) {
+	dir, err := ioutil.TempDir("", "swap-service-test")
+	assert.NilError(t, err)
+	sw := &fakeSwapper{active: make(map[string]pkgswap.Area)}
+	s, err := newService(filepath.Join(dir, "state"), func(path string) ([]string, error) { return refs[path], nil }, sw)
+	assert.NilError(t, err)
+	return s, sw, dir
+}
+
+func TestCreateAndRemove(t *testing.T) {
+	s, sw, dir := newTestService(t, nil)
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+	path := filepath.Join(dir, "swap0")
+
+	priority := 10
+	a, err := s.Create(ctx, swaptypes.CreateOptions{Name: "fast", Path: path, Size: 4096, Priority: &priority})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("fast", a.Name))
+	assert.Check(t, a.Managed)
+	assert.Check(t, a.Active)
+	assert.Check(t, is.Equal(int64(4096), a.Size))
+	assert.Check(t, is.Equal(10, a.Priority))
+
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Name: "fast", Path: filepath.Join(dir, "swap1"), Size: 4096})
+	assert.Check(t, errdefs.IsConflict(err))
+
+	// without a priority, the choice is left to the kernel
+	a, err = s.Create(ctx, swaptypes.CreateOptions{Name: "slow", Path: filepath.Join(dir, "swap1"), Size: 4096})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(-1, a.Priority))
+	assert.NilError(t, s.Remove(ctx, "slow"))
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Name: "other", Path: path})
+	assert.Check(t, errdefs.IsConflict(err))
+
+	assert.NilError(t, s.Remove(ctx, "fast"))
+	assert.Check(t, is.Len(sw.active, 0))
+	_, err = os.Stat(path)
+	assert.Check(t, os.IsNotExist(err))
+
+	_, err = s.Get(ctx, "fast")
+	assert.Check(t, errdefs.IsNotFound(err))
+}
+
+func TestCreateInvalid(t *testing.T) {
+	s, _, dir := newTestService(t, nil)
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	_, err := s.Create(ctx, swaptypes.CreateOptions{Path: "relative/path", Size: 4096})
+	assert.Check(t, errdefs.IsInvalidParameter(err))
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Path: filepath.Join(dir, "missing")})
+	assert.Check(t, errdefs.IsInvalidParameter(err))
+}
+
+func TestRemoveReferenced(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swap-service-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "swap0")
+	s, _, tmp := newTestService(t, map[string][]string{path: {"abcdef"}})
+	defer os.RemoveAll(tmp)
+	ctx := context.Background()
+
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Name: "fast", Path: path, Size: 4096})
+	assert.NilError(t, err)
+
+	a, err := s.Get(ctx, "fast")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"abcdef"}, a.Containers))
+
+	err = s.Remove(ctx, "fast")
+	assert.Check(t, errdefs.IsConflict(err))
+	assert.Check(t, is.ErrorContains(err, "abcdef"))
+
+	rep, err := s.Prune(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(rep.SwapsDeleted, 0))
+}
+
+func TestListAndPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swap-service-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	used := filepath.Join(dir, "used")
+	s, _, tmp := newTestService(t, map[string][]string{used: {"abcdef"}})
+	defer os.RemoveAll(tmp)
+	ctx := context.Background()
+
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Name: "used", Path: used, Size: 4096})
+	assert.NilError(t, err)
+	_, err = s.Create(ctx, swaptypes.CreateOptions{Name: "bulk", Path: filepath.Join(dir, "bulk"), Size: 8192, Labels: map[string]string{"tier": "hdd"}})
+	assert.NilError(t, err)
+
+	ls, err := s.List(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(ls, 2))
+
+	ls, err = s.List(ctx, filters.NewArgs(filters.Arg("dangling", "true")))
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Check(t, is.Equal("bulk", ls[0].Name))
+
+	ls, err = s.List(ctx, filters.NewArgs(filters.Arg("label", "tier=hdd")))
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Check(t, is.Equal("bulk", ls[0].Name))
+
+	_, err = s.List(ctx, filters.NewArgs(filters.Arg("driver", "local")))
+	assert.Check(t, errdefs.IsInvalidParameter(err))
+
+	rep, err := s.Prune(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]string{"bulk"}, rep.SwapsDeleted))
+	assert.Check(t, is.Equal(uint64(8192), rep.SpaceReclaimed))
+}
+
+func TestRestore(t *testing.T) {
+	s, sw, dir := newTestService(t, nil)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "swap0")
+
+	_, err := s.Create(context.Background(), swaptypes.CreateOptions{Name: "fast", Path: path, Size: 4096})
+	assert.NilError(t, err)
+
+	// simulate a reboot of the host
+	delete(sw.active, path)
+
+	s, err = newService(s.root, s.refs, sw)
+	assert.NilError(t, err)
+	a, err := s.Get(context.Background(), "fast")
+	assert.NilError(t, err)
+	assert.Check(t, a.Active)
+}
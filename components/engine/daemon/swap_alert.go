@@ -0,0 +1,94 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+)
+
+// swapPressure is the swap pressure level of a container.
+type swapPressure int
+
+const (
+	swapPressureNone swapPressure = iota
+	swapPressureHigh
+	swapPressureFull
+)
+
+// event returns the container event emitted when entering the level.
+func (p swapPressure) event() string {
+	switch p {
+	case swapPressureHigh:
+		return "swap-high"
+	case swapPressureFull:
+		return "swap-full"
+	}
+	return ""
+}
+
+// getSwapPressure returns the swap pressure level of a container given its
+// swap stats and the threshold, as a percentage of the swap it can use, from
+// which its swap usage is considered high.
+func getSwapPressure(s types.SwapStats, threshold float64) swapPressure {
+	if s.SwapfileSize > 0 && s.SwapfileUsed >= s.SwapfileSize {
+		return swapPressureFull
+	}
+	if s.Limit == 0 {
+		return swapPressureNone
+	}
+	if s.Usage >= s.Limit {
+		return swapPressureFull
+	}
+	if float64(s.Usage)*100 >= threshold*float64(s.Limit) {
+		return swapPressureHigh
+	}
+	return swapPressureNone
+}
+
+func swapPressureAttributes(s types.SwapStats) map[string]string {
+	attributes := map[string]string{
+		"usage": strconv.FormatUint(s.Usage, 10),
+		"limit": strconv.FormatUint(s.Limit, 10),
+	}
+	if s.Limit > 0 {
+		attributes["percent"] = fmt.Sprintf("%.2f", float64(s.Usage)*100/float64(s.Limit))
+	}
+	if s.Swapfile != "" {
+		attributes["swapfile"] = s.Swapfile
+		attributes["swapfileSize"] = strconv.FormatUint(s.SwapfileSize, 10)
+		attributes["swapfileUsed"] = strconv.FormatUint(s.SwapfileUsed, 10)
+	}
+	return attributes
+}
+
+// watchSwapPressure follows the stats of a running container, and emits a
+// "swap-high" or "swap-full" event when its swap pressure level rises. It
+// stops when the container is no longer running, or swap pressure events
+// are disabled.
+func (daemon *Daemon) watchSwapPressure(c *container.Container) {
+	if daemon.swapAlertThreshold() == 0 {
+		return
+	}
+
+	daemon.watchStats(swapPressureWatcher, c, func() statsHandler {
+		level := swapPressureNone
+		return func(s types.StatsJSON) bool {
+			threshold := daemon.swapAlertThreshold()
+			if threshold == 0 {
+				return false
+			}
+			if c.IsHibernated() {
+				// hibernated containers are swapped out on purpose
+				return true
+			}
+			current := getSwapPressure(s.SwapStats, threshold)
+			if current > level {
+				daemon.LogContainerEventWithAttributes(c, current.event(), swapPressureAttributes(s.SwapStats))
+			}
+			level = current
+			return true
+		}
+	}, nil)
+}
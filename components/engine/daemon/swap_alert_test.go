@@ -0,0 +1,68 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestGetSwapPressure(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		stats    types.SwapStats
+		expected swapPressure
+	}{
+		{
+			doc:      "no swap limit",
+			stats:    types.SwapStats{Usage: 100},
+			expected: swapPressureNone,
+		},
+		{
+			doc:      "below threshold",
+			stats:    types.SwapStats{Usage: 79, Limit: 100},
+			expected: swapPressureNone,
+		},
+		{
+			doc:      "at threshold",
+			stats:    types.SwapStats{Usage: 80, Limit: 100},
+			expected: swapPressureHigh,
+		},
+		{
+			doc:      "limit reached",
+			stats:    types.SwapStats{Usage: 100, Limit: 100},
+			expected: swapPressureFull,
+		},
+		{
+			doc:      "swapfile full",
+			stats:    types.SwapStats{Usage: 10, Limit: 100, Swapfile: "/swapfile", SwapfileSize: 1024, SwapfileUsed: 1024},
+			expected: swapPressureFull,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			assert.Check(t, is.Equal(tc.expected, getSwapPressure(tc.stats, 80)))
+		})
+	}
+}
+
+func TestSwapPressureAttributes(t *testing.T) {
+	attributes := swapPressureAttributes(types.SwapStats{
+		Usage:        900,
+		Limit:        1000,
+		Swapfile:     "/swapfile",
+		SwapfileSize: 4096,
+		SwapfileUsed: 2048,
+	})
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"usage":        "900",
+		"limit":        "1000",
+		"percent":      "90.00",
+		"swapfile":     "/swapfile",
+		"swapfileSize": "4096",
+		"swapfileUsed": "2048",
+	}, attributes))
+	assert.Check(t, is.Equal("swap-high", swapPressureHigh.event()))
+	assert.Check(t, is.Equal("swap-full", swapPressureFull.event()))
+}
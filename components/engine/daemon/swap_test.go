@@ -0,0 +1,39 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestSwapReferences(t *testing.T) {
+	replica, err := container.NewViewDB()
+	assert.NilError(t, err)
+	d := &Daemon{containersReplica: replica}
+	for id, swapfile := range map[string]string{
+		"clean":        "/swap/a",
+		"double-slash": "/swap//a",
+		"dot":          "/swap/./a",
+		"other":        "/swap/b",
+		"default":      "default",
+	} {
+		swapfile := swapfile
+		c := container.NewBaseContainer(id, "")
+		c.HostConfig = &containertypes.HostConfig{Resources: containertypes.Resources{MemorySwapfile: &swapfile}}
+		assert.NilError(t, replica.Save(c))
+	}
+
+	for path, expected := range map[string][]string{
+		"/swap/a":  {"clean", "dot", "double-slash"},
+		"/swap/a/": {"clean", "dot", "double-slash"},
+		"/swap/b":  {"other"},
+		"/swap/c":  nil,
+	} {
+		refs, err := d.swapReferences(path)
+		assert.NilError(t, err)
+		assert.Check(t, is.DeepEqual(expected, refs), path)
+	}
+}
@@ -0,0 +1,274 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/cgroup2"
+	"github.com/docker/docker/pkg/swap"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// swapfileDir returns the directory holding daemon-managed swapfiles.
+func (daemon *Daemon) swapfileDir() string {
+	return filepath.Join(daemon.root, "swap")
+}
+
+// setupSwapfile allocates, formats and activates the swapfile of a container
+// that requested a daemon-managed swapfile. The swapfile is kept across
+// restarts of the container, and re-activated if it is no longer active (for
+// example after a reboot of the host). Callers must hold the container lock.
+func (daemon *Daemon) setupSwapfile(c *container.Container) error {
+	if !isAutoSwapfile(c.HostConfig.MemorySwapfile) {
+		return nil
+	}
+
+	p := c.SwapfilePath
+	if p == "" {
+		p = filepath.Join(daemon.swapfileDir(), c.ID)
+	}
+	if _, err := os.Stat(p); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+			return err
+		}
+		if err := swap.Create(p, c.HostConfig.MemorySwapfileSize); err != nil {
+			return errors.Wrap(err, "failed to create swapfile")
+		}
+	}
+	c.SwapfilePath = p
+
+	active, err := swap.IsActive(p)
+	if err != nil {
+		return err
+	}
+	if !active {
+		if err := swap.On(p, -1); err != nil {
+			return errors.Wrap(err, "failed to activate swapfile")
+		}
+	}
+	return nil
+}
+
+// removeSwapfile deactivates and deletes the daemon-managed swapfile of the
+// container, if it has one.
+func (daemon *Daemon) removeSwapfile(c *container.Container) error {
+	if c.SwapfilePath == "" {
+		return nil
+	}
+	active, err := swap.IsActive(c.SwapfilePath)
+	if err != nil {
+		return err
+	}
+	if active {
+		if err := swap.Off(c.SwapfilePath); err != nil {
+			return errors.Wrap(err, "failed to deactivate swapfile")
+		}
+	}
+	if err := os.Remove(c.SwapfilePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	logrus.WithField("container", c.ID).Debugf("removed swapfile %s", c.SwapfilePath)
+	c.SwapfilePath = ""
+	return nil
+}
+
+// setupSwapClass assigns a container that has a swap class to the least
+// utilised active swap area of that class. Callers must hold the container
+// lock.
+func (daemon *Daemon) setupSwapClass(c *container.Container) error {
+	class := c.HostConfig.MemorySwapClass
+	if class == "" {
+		c.SwapClassArea = ""
+		return nil
+	}
+	paths, ok := daemon.configStore.SwapClasses[class]
+	if !ok {
+		return errdefs.InvalidParameter(errors.Errorf("unknown swap class %s", class))
+	}
+	areas, err := daemon.swapHost.GetAreas()
+	if err != nil {
+		return errors.Wrap(err, "failed to read the active swap areas")
+	}
+	area, err := pickSwapArea(paths, areas)
+	if err != nil {
+		return errors.Wrapf(err, "swap class %s", class)
+	}
+	c.SwapClassArea = area
+	logrus.WithField("container", c.ID).Debugf("assigned swap area %s from swap class %s", area, class)
+	return nil
+}
+
+// pickSwapArea returns the least utilised of the active areas listed in
+// paths. Ties are broken by the order of paths.
+func pickSwapArea(paths []string, areas []swap.Area) (string, error) {
+	var (
+		picked   string
+		minUsage float64
+	)
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		for _, a := range areas {
+			if a.Path != p || a.Size <= 0 {
+				continue
+			}
+			usage := float64(a.Used) / float64(a.Size)
+			if picked == "" || usage < minUsage {
+				picked, minUsage = a.Path, usage
+			}
+		}
+	}
+	if picked == "" {
+		return "", errdefs.NotFound(errors.New("none of the swap areas is active"))
+	}
+	return picked, nil
+}
+
+// Outcomes of the reconciliation of the swap area of a container, reported in
+// the "status" attribute of the swap-reconcile event.
+const (
+	swapReactivated = "reactivated" // the daemon-managed swapfile was activated again
+	swapReassigned  = "reassigned"  // the container was moved to another area of its swap class
+	swapReapplied   = "reapplied"   // the swap area was applied to the cgroup again
+	swapInactive    = "inactive"    // the swap area is inactive and could not be replaced
+	swapFailed      = "failed"      // the swap area could not be applied to the cgroup
+)
+
+// reconcileSwap verifies, when restoring a running container, that the swap
+// area it is assigned to is still active and still applied to its cgroup,
+// and repairs the assignment where possible. A "swap-reconcile" event is
+// emitted when a mismatch is found, and a warning is recorded in the
+// container state when it cannot be repaired.
+func (daemon *Daemon) reconcileSwap(c *container.Container) {
+	c.Lock()
+	previous := c.State.SwapWarning
+	status, warning := daemon.verifySwapAssignment(c)
+	c.State.SwapWarning = warning
+	if status != "" || warning != previous {
+		if err := c.CheckpointTo(daemon.containersReplica); err != nil {
+			logrus.WithError(err).WithField("container", c.ID).Warn("could not save container to disk")
+		}
+	}
+	attributes := map[string]string{
+		"swapfile": c.SwapArea(),
+		"status":   status,
+	}
+	c.Unlock()
+
+	if warning != "" {
+		logrus.WithField("container", c.ID).Warn(warning)
+	}
+	if status != "" {
+		daemon.LogContainerEventWithAttributes(c, "swap-reconcile", attributes)
+	}
+}
+
+// verifySwapAssignment checks the swap area of a running container, and
+// returns how its assignment was repaired, if it had to be, along with a
+// warning if it could not be. Callers must hold the container lock.
+func (daemon *Daemon) verifySwapAssignment(c *container.Container) (status, warning string) {
+	swapfile := c.SwapArea()
+	if swapfile == "" {
+		return "", ""
+	}
+	swapfile = filepath.Clean(swapfile)
+	active, err := daemon.swapHost.IsActive(swapfile)
+	if err != nil {
+		return "", fmt.Sprintf("failed to verify swap area %s: %v", swapfile, err)
+	}
+	if !active {
+		switch {
+		case isAutoSwapfile(c.HostConfig.MemorySwapfile):
+			if err := daemon.swapHost.On(swapfile, -1); err != nil {
+				return swapInactive, fmt.Sprintf("swap area %s is no longer active and could not be activated again: %v", swapfile, err)
+			}
+			status = swapReactivated
+		case c.HostConfig.MemorySwapClass != "":
+			if err := daemon.setupSwapClass(c); err != nil {
+				return swapInactive, fmt.Sprintf("swap area %s is no longer active: %v", swapfile, err)
+			}
+			status = swapReassigned
+			swapfile = c.SwapClassArea
+		default:
+			return swapInactive, fmt.Sprintf("swap area %s is no longer active", swapfile)
+		}
+	}
+
+	if status == "" {
+		applied, err := daemon.swapCgroups.Swapfile(c.Pid)
+		if os.IsNotExist(err) {
+			// the kernel does not report the swap area of the cgroup
+			return "", ""
+		}
+		if err != nil {
+			return "", fmt.Sprintf("failed to verify swap area %s: %v", swapfile, err)
+		}
+		if applied == swapfile {
+			return "", ""
+		}
+		status = swapReapplied
+	}
+
+	if c.Hibernated {
+		// the memory limits, swap area included, are applied again on resume
+		return status, ""
+	}
+	if err := daemon.restoreMemory(c); err != nil {
+		return swapFailed, fmt.Sprintf("failed to apply swap area %s: %v", swapfile, err)
+	}
+	// restoring the memory limits lifts the limit lowered by the idle
+	// policy, which lowers it again if the container is still idle
+	c.Idle = false
+	return status, ""
+}
+
+// Swapfile returns the swap area applied to the memory cgroup of the process
+// with the given pid.
+func (hostCgroups) Swapfile(pid int) (string, error) {
+	var dir string
+	if cgroup2.IsEnabled() {
+		p, err := cgroup2.ProcessPath(pid)
+		if err != nil {
+			return "", err
+		}
+		dir = p
+	} else {
+		paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+		if err != nil {
+			return "", err
+		}
+		mnt, root, err := cgroups.FindCgroupMountpointAndRoot("", "memory")
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(root, paths["memory"])
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(mnt, rel)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "memory.swapfile"))
+	if err != nil {
+		return "", err
+	}
+	return parseCgroupSwapfile(string(b)), nil
+}
+
+// parseCgroupSwapfile parses the content of memory.swapfile, returning an
+// empty string if the cgroup uses the default swap areas of the host.
+func parseCgroupSwapfile(content string) string {
+	v := strings.TrimSpace(content)
+	if v == "" || v == swapfileDefault {
+		return ""
+	}
+	return filepath.Clean(v)
+}
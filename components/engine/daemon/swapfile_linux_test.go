@@ -0,0 +1,319 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/daemon/events"
+	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/pkg/swap"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestPickSwapArea(t *testing.T) {
+	areas := []swap.Area{
+		{Path: "/dev/nvme0n1p3", Type: "partition", Size: 1000, Used: 500},
+		{Path: "/dev/nvme1n1p3", Type: "partition", Size: 1000, Used: 100},
+		{Path: "/mnt/hdd/swapfile", Type: "file", Size: 4000, Used: 0},
+	}
+
+	testCases := []struct {
+		doc      string
+		paths    []string
+		expected string
+		err      string
+	}{
+		{
+			doc:      "least utilised area",
+			paths:    []string{"/dev/nvme0n1p3", "/dev/nvme1n1p3"},
+			expected: "/dev/nvme1n1p3",
+		},
+		{
+			doc:      "inactive areas are skipped",
+			paths:    []string{"/dev/sdb2", "/dev/nvme0n1p3"},
+			expected: "/dev/nvme0n1p3",
+		},
+		{
+			doc:      "paths are cleaned",
+			paths:    []string{"/mnt/hdd/../hdd/swapfile"},
+			expected: "/mnt/hdd/swapfile",
+		},
+		{
+			doc:   "no active area",
+			paths: []string{"/dev/sdb2"},
+			err:   "none of the swap areas is active",
+		},
+	}
+
+	for _, tc := range testCases {
+		area, err := pickSwapArea(tc.paths, areas)
+		if tc.err != "" {
+			assert.Check(t, is.ErrorContains(err, tc.err), tc.doc)
+			continue
+		}
+		assert.Check(t, err, tc.doc)
+		assert.Check(t, is.Equal(tc.expected, area), tc.doc)
+	}
+}
+
+func TestPickSwapAreaTie(t *testing.T) {
+	areas := []swap.Area{
+		{Path: "/dev/sdb2", Size: 1000, Used: 100},
+		{Path: "/dev/sda2", Size: 2000, Used: 200},
+	}
+	area, err := pickSwapArea([]string{"/dev/sda2", "/dev/sdb2"}, areas)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("/dev/sda2", area))
+}
+
+func TestParseCgroupSwapfile(t *testing.T) {
+	assert.Check(t, is.Equal("", parseCgroupSwapfile("")))
+	assert.Check(t, is.Equal("", parseCgroupSwapfile("default\n")))
+	assert.Check(t, is.Equal("/mnt/ssd/swapfile", parseCgroupSwapfile("/mnt/ssd/../ssd/swapfile\n")))
+}
+
+type fakeSwapHost struct {
+	active map[string]bool
+	areas  []swap.Area
+	onErr  error
+}
+
+func (h *fakeSwapHost) IsActive(path string) (bool, error) {
+	return h.active[path], nil
+}
+
+func (h *fakeSwapHost) On(path string, priority int) error {
+	if h.onErr != nil {
+		return h.onErr
+	}
+	h.active[path] = true
+	return nil
+}
+
+func (h *fakeSwapHost) GetAreas() ([]swap.Area, error) {
+	return h.areas, nil
+}
+
+type fakeSwapCgroups struct {
+	swapfile string
+	err      error
+}
+
+func (r fakeSwapCgroups) Swapfile(pid int) (string, error) {
+	return r.swapfile, r.err
+}
+
+type updateResourcesMockContainerdClient struct {
+	MockContainerdClient
+	err     error
+	updates int
+}
+
+func (c *updateResourcesMockContainerdClient) UpdateResources(ctx context.Context, containerID string, resources *libcontainerd.Resources) error {
+	c.updates++
+	return c.err
+}
+
+type swapAssignmentTestCase struct {
+	doc        string
+	swapfile   string // the MemorySwapfile option of the container
+	swapClass  string
+	assigned   string // the daemon-managed swapfile, or the area of the swap class
+	active     []string
+	onErr      error
+	applied    fakeSwapCgroups
+	updateErr  error
+	hibernated bool
+
+	status   string
+	warning  string
+	area     string // the swap area of the container once verified
+	restored bool   // whether the memory limits were applied again
+}
+
+var swapAssignmentTestCases = []swapAssignmentTestCase{
+	{
+		doc: "no swap area",
+	},
+	{
+		doc:      "swap area applied",
+		swapfile: "/mnt/ssd/swapfile",
+		active:   []string{"/mnt/ssd/swapfile"},
+		applied:  fakeSwapCgroups{swapfile: "/mnt/ssd/swapfile"},
+		area:     "/mnt/ssd/swapfile",
+	},
+	{
+		doc:      "swap area of the cgroup not reported",
+		swapfile: "/mnt/ssd/swapfile",
+		active:   []string{"/mnt/ssd/swapfile"},
+		applied:  fakeSwapCgroups{err: os.ErrNotExist},
+		area:     "/mnt/ssd/swapfile",
+	},
+	{
+		doc:      "daemon-managed swapfile reactivated",
+		swapfile: "auto",
+		assigned: "/var/lib/docker/swap/container_id",
+		status:   swapReactivated,
+		area:     "/var/lib/docker/swap/container_id",
+		restored: true,
+	},
+	{
+		doc:       "swap class area reassigned",
+		swapClass: "fast",
+		assigned:  "/dev/nvme0n1p3",
+		active:    []string{"/dev/nvme1n1p3"},
+		status:    swapReassigned,
+		area:      "/dev/nvme1n1p3",
+		restored:  true,
+	},
+	{
+		doc:      "swap area reapplied",
+		swapfile: "/mnt/ssd/swapfile",
+		active:   []string{"/mnt/ssd/swapfile"},
+		status:   swapReapplied,
+		area:     "/mnt/ssd/swapfile",
+		restored: true,
+	},
+	{
+		doc:        "swap area reapplied on resume",
+		swapfile:   "/mnt/ssd/swapfile",
+		active:     []string{"/mnt/ssd/swapfile"},
+		hibernated: true,
+		status:     swapReapplied,
+		area:       "/mnt/ssd/swapfile",
+	},
+	{
+		doc:      "swap area inactive",
+		swapfile: "/mnt/ssd/swapfile",
+		status:   swapInactive,
+		warning:  "swap area /mnt/ssd/swapfile is no longer active",
+		area:     "/mnt/ssd/swapfile",
+	},
+	{
+		doc:      "daemon-managed swapfile not activated",
+		swapfile: "auto",
+		assigned: "/var/lib/docker/swap/container_id",
+		onErr:    errors.New("operation not permitted"),
+		status:   swapInactive,
+		warning:  "could not be activated again: operation not permitted",
+		area:     "/var/lib/docker/swap/container_id",
+	},
+	{
+		doc:       "swap class without active area",
+		swapClass: "fast",
+		assigned:  "/dev/nvme0n1p3",
+		status:    swapInactive,
+		warning:   "none of the swap areas is active",
+		area:      "/dev/nvme0n1p3",
+	},
+	{
+		doc:       "swap area not applied",
+		swapfile:  "/mnt/ssd/swapfile",
+		active:    []string{"/mnt/ssd/swapfile"},
+		updateErr: errors.New("cgroup not found"),
+		status:    swapFailed,
+		warning:   "failed to apply swap area /mnt/ssd/swapfile: cgroup not found",
+		area:      "/mnt/ssd/swapfile",
+		restored:  true,
+	},
+}
+
+// newSwapAssignmentTest returns a daemon with fake swap areas and cgroups,
+// and a running container, as described by the test case.
+func newSwapAssignmentTest(t *testing.T, root string, tc swapAssignmentTestCase) (*Daemon, *updateResourcesMockContainerdClient, *container.Container) {
+	host := &fakeSwapHost{active: map[string]bool{}}
+	for _, p := range tc.active {
+		host.active[p] = true
+		host.areas = append(host.areas, swap.Area{Path: p, Size: 1000})
+	}
+	host.onErr = tc.onErr
+	client := &updateResourcesMockContainerdClient{err: tc.updateErr}
+
+	replica, err := container.NewViewDB()
+	assert.NilError(t, err)
+	d := &Daemon{
+		configStore:       &config.Config{},
+		containersReplica: replica,
+		EventsService:     events.New(),
+		containerd:        client,
+		swapHost:          host,
+		swapCgroups:       tc.applied,
+	}
+	d.configStore.SwapClasses = map[string][]string{"fast": {"/dev/nvme0n1p3", "/dev/nvme1n1p3"}}
+
+	c := &container.Container{
+		ID:         "container_id",
+		Root:       root,
+		State:      container.NewState(),
+		Config:     &containertypes.Config{},
+		HostConfig: &containertypes.HostConfig{},
+	}
+	c.State.Pid = 1
+	c.Hibernated = tc.hibernated
+	if tc.swapfile != "" {
+		swapfile := tc.swapfile
+		c.HostConfig.MemorySwapfile = &swapfile
+	}
+	c.HostConfig.MemorySwapClass = tc.swapClass
+	if tc.swapfile == "auto" {
+		c.SwapfilePath = tc.assigned
+	} else {
+		c.SwapClassArea = tc.assigned
+	}
+	return d, client, c
+}
+
+func TestVerifySwapAssignment(t *testing.T) {
+	root, err := ioutil.TempDir("", "swap-assignment")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	for _, tc := range swapAssignmentTestCases {
+		d, client, c := newSwapAssignmentTest(t, root, tc)
+		status, warning := d.verifySwapAssignment(c)
+		assert.Check(t, is.Equal(tc.status, status), tc.doc)
+		if tc.warning == "" {
+			assert.Check(t, is.Equal("", warning), tc.doc)
+		} else {
+			assert.Check(t, is.Contains(warning, tc.warning), tc.doc)
+		}
+		assert.Check(t, is.Equal(tc.area, c.SwapArea()), tc.doc)
+		assert.Check(t, is.Equal(tc.restored, client.updates == 1), tc.doc)
+	}
+}
+
+func TestReconcileSwap(t *testing.T) {
+	root, err := ioutil.TempDir("", "swap-assignment")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	for _, tc := range swapAssignmentTestCases {
+		d, _, c := newSwapAssignmentTest(t, root, tc)
+		c.State.SwapWarning = "swap area /dev/sdb2 is no longer active"
+		d.reconcileSwap(c)
+
+		if tc.warning == "" {
+			assert.Check(t, is.Equal("", c.State.SwapWarning), tc.doc)
+		} else {
+			assert.Check(t, is.Contains(c.State.SwapWarning, tc.warning), tc.doc)
+		}
+		messages, l, _ := d.EventsService.Subscribe()
+		d.EventsService.Evict(l)
+		if tc.status == "" {
+			assert.Check(t, is.Len(messages, 0), tc.doc)
+			continue
+		}
+		if assert.Check(t, is.Len(messages, 1), tc.doc) {
+			assert.Check(t, is.Equal("swap-reconcile", messages[0].Action), tc.doc)
+			assert.Check(t, is.Equal(tc.status, messages[0].Actor.Attributes["status"]), tc.doc)
+			assert.Check(t, is.Equal(tc.area, messages[0].Actor.Attributes["swapfile"]), tc.doc)
+		}
+	}
+}
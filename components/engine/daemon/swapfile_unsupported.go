@@ -0,0 +1,28 @@
+// +build !linux
+
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"errors"
+
+	"github.com/docker/docker/container"
+)
+
+func (daemon *Daemon) setupSwapfile(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) removeSwapfile(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) setupSwapClass(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) reconcileSwap(c *container.Container) {
+}
+
+func (hostCgroups) Swapfile(pid int) (string, error) {
+	return "", errors.New("swap areas are not supported on this platform")
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/docker/docker/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,6 +29,12 @@ func (daemon *Daemon) containerUnpause(container *container.Container) error {
 		return fmt.Errorf("Container %s is not paused", container.ID)
 	}
 
+	// We cannot unpause the container which is hibernated, as its memory
+	// limits have to be restored first
+	if container.Hibernated {
+		return errdefs.Conflict(errors.Errorf("Container %s is hibernated, resume the container instead", container.ID))
+	}
+
 	if err := daemon.containerd.Resume(context.Background(), container.ID); err != nil {
 		return fmt.Errorf("Cannot unpause container %s: %s", container.ID, err)
 	}
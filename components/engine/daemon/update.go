@@ -55,6 +55,10 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return errCannotUpdate(container.ID, fmt.Errorf("container is marked for removal and cannot be \"update\""))
 	}
 
+	if container.IsHibernated() {
+		return errCannotUpdate(container.ID, errdefs.Conflict(fmt.Errorf("container is hibernated, resume the container before updating it")))
+	}
+
 	container.Lock()
 	if err := container.UpdateContainer(hostConfig); err != nil {
 		restoreConfig = true
@@ -66,6 +70,7 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		container.Unlock()
 		return errCannotUpdate(container.ID, err)
 	}
+	resources := containerResources(container)
 	container.Unlock()
 
 	// if Restart Policy changed, we need to update container monitor
@@ -78,11 +83,15 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	// If container is running (including paused), we need to update configs
 	// to the real world.
 	if container.IsRunning() && !container.IsRestarting() {
-		if err := daemon.containerd.UpdateResources(context.Background(), container.ID, toContainerdResources(hostConfig.Resources)); err != nil {
+		if err := daemon.containerd.UpdateResources(context.Background(), container.ID, toContainerdUpdateResources(hostConfig.Resources, resources)); err != nil {
 			restoreConfig = true
 			// TODO: it would be nice if containerd responded with better errors here so we can classify this better.
 			return errCannotUpdate(container.ID, errdefs.System(err))
 		}
+		// the memory limit lowered by the idle policy has been overwritten
+		container.Lock()
+		container.Idle = false
+		container.Unlock()
 	}
 
 	daemon.LogContainerEvent(container, "update")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
@@ -50,5 +51,33 @@ func toContainerdResources(resources container.Resources) *libcontainerd.Resourc
 		r.Memory.Swap = &resources.MemorySwap
 	}
 
+	if resources.MemorySwappiness != nil {
+		swappiness := uint64(*resources.MemorySwappiness)
+		r.Memory.Swappiness = &swappiness
+	}
+
+	if resources.MemorySwapfile != nil {
+		swapfile := *resources.MemorySwapfile
+		r.Memory.Swapfile = &swapfile
+	}
+
+	if cgroup2.IsEnabled() {
+		r.Unified = cgroup2.Resources((*specs.LinuxResources)(&r))
+	}
+
 	return &r
 }
+
+// toContainerdUpdateResources returns the resources to apply to a running
+// container for an update. The cgroup v1 resources only hold the fields set
+// by the update, as the runtime leaves the others unchanged. The cgroup v2
+// files are derived from the resources of the container after the update
+// instead: memory.swap.max depends on both memory limits, and a limit reset
+// to unlimited must be written explicitly.
+func toContainerdUpdateResources(update, resources container.Resources) *libcontainerd.Resources {
+	r := toContainerdResources(update)
+	if cgroup2.IsEnabled() {
+		r.Unified = toContainerdResources(resources).Unified
+	}
+	return r
+}
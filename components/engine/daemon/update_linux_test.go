@@ -0,0 +1,97 @@
+package daemon // import "github.com/docker/docker/daemon"
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/typeurl"
+	"github.com/docker/docker/api/types/container"
+	containerpkg "github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/cgroup2"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestToContainerdResourcesSwap(t *testing.T) {
+	swappiness := int64(10)
+	swapfile := "/swap/nvme0"
+
+	r := toContainerdResources(container.Resources{
+		Memory:           64 * 1024 * 1024,
+		MemorySwap:       128 * 1024 * 1024,
+		MemorySwappiness: &swappiness,
+		MemorySwapfile:   &swapfile,
+	})
+	assert.Assert(t, r.Memory.Swap != nil)
+	assert.Check(t, is.Equal(int64(128*1024*1024), *r.Memory.Swap))
+	assert.Assert(t, r.Memory.Swappiness != nil)
+	assert.Check(t, is.Equal(uint64(10), *r.Memory.Swappiness))
+	assert.Assert(t, r.Memory.Swapfile != nil)
+	assert.Check(t, is.Equal(swapfile, *r.Memory.Swapfile))
+
+	r = toContainerdResources(container.Resources{})
+	assert.Check(t, is.Nil(r.Memory.Swap))
+	assert.Check(t, is.Nil(r.Memory.Swappiness))
+	assert.Check(t, is.Nil(r.Memory.Swapfile))
+}
+
+func TestUpdateUnifiedResources(t *testing.T) {
+	var (
+		memory int64 = 64 * 1024 * 1024
+		memsw  int64 = 128 * 1024 * 1024
+	)
+	c := &containerpkg.Container{
+		HostConfig: &container.HostConfig{
+			Resources: container.Resources{
+				Memory:            memory,
+				MemorySwap:        memsw,
+				MemoryReservation: 32 * 1024 * 1024,
+			},
+		},
+	}
+	unified := func(update container.Resources) map[string]string {
+		assert.NilError(t, c.UpdateContainer(&container.HostConfig{Resources: update}))
+		return cgroup2.Resources((*specs.LinuxResources)(toContainerdResources(c.HostConfig.Resources)))
+	}
+
+	// lowering the swap limit to the memory limit disables swap
+	u := unified(container.Resources{MemorySwap: memory})
+	assert.Check(t, is.Equal("67108864", u["memory.max"]))
+	assert.Check(t, is.Equal("0", u["memory.swap.max"]))
+	assert.Check(t, is.Equal("33554432", u["memory.low"]))
+
+	// raising the memory limit keeps the memory+swap limit of the container
+	u = unified(container.Resources{Memory: 96 * 1024 * 1024, MemorySwap: memsw})
+	assert.Check(t, is.Equal("100663296", u["memory.max"]))
+	assert.Check(t, is.Equal("33554432", u["memory.swap.max"]))
+
+	// clearing the swap limit lifts memory.swap.max
+	u = unified(container.Resources{MemorySwap: -1})
+	assert.Check(t, is.Equal("100663296", u["memory.max"]))
+	assert.Check(t, is.Equal("max", u["memory.swap.max"]))
+}
+
+func TestUpdateResourcesRoundTrip(t *testing.T) {
+	r := toContainerdResources(container.Resources{Memory: 64 * 1024 * 1024})
+	r.Unified = map[string]string{"memory.max": "67108864", "memory.swap.max": "max"}
+
+	// the resources are sent to the shim as in (*task).Update, which decodes
+	// them before passing them on to the runtime
+	var info containerd.UpdateTaskInfo
+	assert.NilError(t, containerd.WithResources((*specs.LinuxResources)(r))(context.Background(), nil, &info))
+	any, err := typeurl.MarshalAny(info.Resources)
+	assert.NilError(t, err)
+	var decoded specs.LinuxResources
+	assert.NilError(t, json.Unmarshal(any.Value, &decoded))
+	assert.Check(t, is.DeepEqual(r.Unified, decoded.Unified))
+}
+
+func TestUpdateSwapClass(t *testing.T) {
+	c := &containerpkg.Container{HostConfig: &container.HostConfig{}}
+	err := c.UpdateContainer(&container.HostConfig{Resources: container.Resources{MemorySwapClass: "fast"}})
+	assert.Check(t, is.ErrorContains(err, "A swap class can only be set when creating the container"))
+	assert.Check(t, is.Equal("", c.HostConfig.MemorySwapClass))
+}
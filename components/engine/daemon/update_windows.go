@@ -9,3 +9,7 @@ func toContainerdResources(resources container.Resources) *libcontainerd.Resourc
 	// We don't support update, so do nothing
 	return nil
 }
+
+func toContainerdUpdateResources(update, resources container.Resources) *libcontainerd.Resources {
+	return nil
+}
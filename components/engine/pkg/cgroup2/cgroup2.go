@@ -0,0 +1,62 @@
+// Package cgroup2 reads and translates the controller files of the cgroup v2
+// unified hierarchy.
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+// Stats holds the statistics of a cgroup, as read from its controller files.
+type Stats struct {
+	// Memory holds the counters of memory.stat.
+	Memory map[string]uint64
+	// MemoryEvents holds the counters of memory.events.
+	MemoryEvents map[string]uint64
+	// MemoryCurrent is the memory usage of the cgroup, from memory.current.
+	MemoryCurrent uint64
+	// MemoryMax is the memory limit of the cgroup, from memory.max. It is
+	// math.MaxUint64 if there is no limit.
+	MemoryMax uint64
+	// SwapCurrent is the swap usage of the cgroup, from memory.swap.current.
+	SwapCurrent uint64
+	// SwapMax is the swap limit of the cgroup, from memory.swap.max. It is
+	// math.MaxUint64 if there is no limit.
+	SwapMax uint64
+	// CPU holds the counters of cpu.stat.
+	CPU map[string]uint64
+	// IO holds the per-device counters of io.stat.
+	IO []IOEntry
+	// PidsCurrent is the number of processes in the cgroup, from pids.current.
+	PidsCurrent uint64
+	// PidsMax is the process limit of the cgroup, from pids.max. It is
+	// math.MaxUint64 if there is no limit.
+	PidsMax uint64
+	// CPUPressure, MemoryPressure and IOPressure hold the pressure stall
+	// information of the cgroup. They are nil if the kernel does not
+	// support PSI.
+	CPUPressure    *Pressure
+	MemoryPressure *Pressure
+	IOPressure     *Pressure
+}
+
+// IOEntry holds the io.stat counters of a block device.
+type IOEntry struct {
+	Major uint64
+	Minor uint64
+	// Stats holds the counters of the device, such as "rbytes" or "wios".
+	Stats map[string]uint64
+}
+
+// Pressure holds the pressure stall information of a resource.
+type Pressure struct {
+	// Some is the share of time some of the tasks were stalled on the resource.
+	Some *PressureData
+	// Full is the share of time all of the tasks were stalled on the resource.
+	// The kernel does not report it for the cpu resource.
+	Full *PressureData
+}
+
+// PressureData holds the stall ratios, in percent, averaged over 10, 60 and
+// 300 seconds, and the total stall time in microseconds.
+type PressureData struct {
+	Avg10  float64
+	Avg60  float64
+	Avg300 float64
+	Total  uint64
+}
@@ -0,0 +1,266 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// Mountpoint is where the unified hierarchy is mounted.
+const Mountpoint = "/sys/fs/cgroup"
+
+var (
+	enabledOnce sync.Once
+	enabled     bool
+)
+
+// IsEnabled returns true if the host runs the cgroup v2 unified hierarchy,
+// that is if it is mounted on Mountpoint.
+func IsEnabled() bool {
+	enabledOnce.Do(func() {
+		var st unix.Statfs_t
+		if err := unix.Statfs(Mountpoint, &st); err == nil {
+			enabled = st.Type == unix.CGROUP2_SUPER_MAGIC
+		}
+	})
+	return enabled
+}
+
+// Controllers returns the controllers available in the unified hierarchy.
+func Controllers() ([]string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(Mountpoint, "cgroup.controllers"))
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(b)), nil
+}
+
+// ProcessPath returns the directory of the cgroup of the process with the
+// given pid.
+func ProcessPath(pid int) (string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	p, err := parseProcCgroup(f)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(Mountpoint, p), nil
+}
+
+func parseProcCgroup(r io.Reader) (string, error) {
+	// the unified hierarchy is listed as "0::/path"
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if p := strings.TrimPrefix(s.Text(), "0::"); p != s.Text() {
+			return p, nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("process is not in the unified hierarchy")
+}
+
+// ReadStats reads the statistics of the cgroup in dir. Controller files that
+// do not exist, for example because the controller is not enabled for the
+// cgroup, are skipped.
+func ReadStats(dir string) (*Stats, error) {
+	s := &Stats{}
+	var err error
+	if s.Memory, err = readKeyValues(dir, "memory.stat"); err != nil {
+		return nil, err
+	}
+	if s.MemoryEvents, err = readKeyValues(dir, "memory.events"); err != nil {
+		return nil, err
+	}
+	if s.CPU, err = readKeyValues(dir, "cpu.stat"); err != nil {
+		return nil, err
+	}
+	for file, v := range map[string]*uint64{
+		"memory.current":      &s.MemoryCurrent,
+		"memory.max":          &s.MemoryMax,
+		"memory.swap.current": &s.SwapCurrent,
+		"memory.swap.max":     &s.SwapMax,
+		"pids.current":        &s.PidsCurrent,
+		"pids.max":            &s.PidsMax,
+	} {
+		if *v, err = readUint(dir, file); err != nil {
+			return nil, err
+		}
+	}
+	if s.IO, err = readIOStat(dir); err != nil {
+		return nil, err
+	}
+	for file, p := range map[string]**Pressure{
+		"cpu.pressure":    &s.CPUPressure,
+		"memory.pressure": &s.MemoryPressure,
+		"io.pressure":     &s.IOPressure,
+	} {
+		if *p, err = readPressure(dir, file); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// readFile returns the content of a controller file, or nil if it does not
+// exist.
+func readFile(dir, file string) ([]byte, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// readUint reads a single value controller file, where "max" stands for no
+// limit and is returned as math.MaxUint64.
+func readUint(dir, file string) (uint64, error) {
+	b, err := readFile(dir, file)
+	if err != nil || b == nil {
+		return 0, err
+	}
+	v := strings.TrimSpace(string(b))
+	if v == maxValue {
+		return math.MaxUint64, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value %q", file, v)
+	}
+	return n, nil
+}
+
+// readKeyValues reads a flat keyed controller file, such as memory.stat.
+func readKeyValues(dir, file string) (map[string]uint64, error) {
+	b, err := readFile(dir, file)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return parseKeyValues(file, string(b))
+}
+
+func parseKeyValues(file, content string) (map[string]uint64, error) {
+	out := make(map[string]uint64)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid %s line %q", file, line)
+		}
+		n, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s line %q", file, line)
+		}
+		out[fields[0]] = n
+	}
+	return out, nil
+}
+
+func readIOStat(dir string) ([]IOEntry, error) {
+	b, err := readFile(dir, "io.stat")
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return parseIOStat(string(b))
+}
+
+func parseIOStat(content string) ([]IOEntry, error) {
+	/*
+	   8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+	*/
+	var out []IOEntry
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var e IOEntry
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &e.Major, &e.Minor); err != nil {
+			return nil, fmt.Errorf("invalid io.stat line %q", line)
+		}
+		e.Stats = make(map[string]uint64)
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid io.stat line %q", line)
+			}
+			n, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid io.stat line %q", line)
+			}
+			e.Stats[kv[0]] = n
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+func readPressure(dir, file string) (*Pressure, error) {
+	b, err := readFile(dir, file)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	return parsePressure(file, string(b))
+}
+
+func parsePressure(file, content string) (*Pressure, error) {
+	/*
+	   some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+	   full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+	*/
+	p := &Pressure{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("invalid %s line %q", file, line)
+		}
+		d := &PressureData{}
+		for _, f := range fields[1:] {
+			kv := strings.SplitN(f, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid %s line %q", file, line)
+			}
+			var err error
+			switch kv[0] {
+			case "avg10":
+				d.Avg10, err = strconv.ParseFloat(kv[1], 64)
+			case "avg60":
+				d.Avg60, err = strconv.ParseFloat(kv[1], 64)
+			case "avg300":
+				d.Avg300, err = strconv.ParseFloat(kv[1], 64)
+			case "total":
+				d.Total, err = strconv.ParseUint(kv[1], 10, 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s line %q", file, line)
+			}
+		}
+		switch fields[0] {
+		case "some":
+			p.Some = d
+		case "full":
+			p.Full = d
+		default:
+			return nil, fmt.Errorf("invalid %s line %q", file, line)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,84 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestParseProcCgroup(t *testing.T) {
+	p, err := parseProcCgroup(strings.NewReader("0::/system.slice/docker-abc.scope\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal("/system.slice/docker-abc.scope", p))
+
+	_, err = parseProcCgroup(strings.NewReader("4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n"))
+	assert.Check(t, is.ErrorContains(err, "not in the unified hierarchy"))
+}
+
+func TestParsePressure(t *testing.T) {
+	p, err := parsePressure("memory.pressure", `some avg10=1.50 avg60=0.75 avg300=0.10 total=123456
+full avg10=0.50 avg60=0.25 avg300=0.00 total=65432
+`)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(&Pressure{
+		Some: &PressureData{Avg10: 1.5, Avg60: 0.75, Avg300: 0.1, Total: 123456},
+		Full: &PressureData{Avg10: 0.5, Avg60: 0.25, Avg300: 0, Total: 65432},
+	}, p))
+
+	_, err = parsePressure("cpu.pressure", "some avg10=foo avg60=0.00 avg300=0.00 total=0\n")
+	assert.Check(t, is.ErrorContains(err, "invalid cpu.pressure line"))
+}
+
+func TestParseIOStat(t *testing.T) {
+	entries, err := parseIOStat("8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353\n259:1 rbytes=4096 wbytes=0 rios=1 wios=0\n")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]IOEntry{
+		{Major: 8, Minor: 0, Stats: map[string]uint64{"rbytes": 1459200, "wbytes": 314773504, "rios": 192, "wios": 353}},
+		{Major: 259, Minor: 1, Stats: map[string]uint64{"rbytes": 4096, "wbytes": 0, "rios": 1, "wios": 0}},
+	}, entries))
+
+	_, err = parseIOStat("sda rbytes=0\n")
+	assert.Check(t, is.ErrorContains(err, "invalid io.stat line"))
+}
+
+func TestReadStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	for file, content := range map[string]string{
+		"memory.current":      "104857600\n",
+		"memory.max":          "max\n",
+		"memory.swap.current": "4096\n",
+		"memory.swap.max":     "1073741824\n",
+		"memory.stat":         "anon 52428800\nfile 41943040\npgmajfault 12\n",
+		"memory.events":       "low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n",
+		"cpu.stat":            "usage_usec 2000\nuser_usec 1500\nsystem_usec 500\n",
+		"pids.current":        "7\n",
+		"memory.pressure":     "some avg10=0.00 avg60=0.00 avg300=0.00 total=10\nfull avg10=0.00 avg60=0.00 avg300=0.00 total=5\n",
+	} {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	}
+
+	s, err := ReadStats(dir)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(uint64(104857600), s.MemoryCurrent))
+	assert.Check(t, is.Equal(uint64(math.MaxUint64), s.MemoryMax))
+	assert.Check(t, is.Equal(uint64(4096), s.SwapCurrent))
+	assert.Check(t, is.Equal(uint64(1073741824), s.SwapMax))
+	assert.Check(t, is.Equal(uint64(12), s.Memory["pgmajfault"]))
+	assert.Check(t, is.Equal(uint64(1), s.MemoryEvents["oom_kill"]))
+	assert.Check(t, is.Equal(uint64(2000), s.CPU["usage_usec"]))
+	assert.Check(t, is.Equal(uint64(7), s.PidsCurrent))
+	assert.Check(t, is.Equal(uint64(0), s.PidsMax), "pids.max does not exist")
+	assert.Check(t, is.Len(s.IO, 0))
+	assert.Check(t, is.Nil(s.CPUPressure))
+	assert.Assert(t, s.MemoryPressure != nil)
+	assert.Check(t, is.Equal(uint64(5), s.MemoryPressure.Full.Total))
+}
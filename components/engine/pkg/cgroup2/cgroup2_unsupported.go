@@ -0,0 +1,30 @@
+// +build !linux
+
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"fmt"
+	"runtime"
+)
+
+var errNotSupported = fmt.Errorf("cgroup v2 is not supported on %s/%s", runtime.GOOS, runtime.GOARCH)
+
+// IsEnabled always returns false on this platform.
+func IsEnabled() bool {
+	return false
+}
+
+// Controllers is not supported on this platform.
+func Controllers() ([]string, error) {
+	return nil, errNotSupported
+}
+
+// ProcessPath is not supported on this platform.
+func ProcessPath(pid int) (string, error) {
+	return "", errNotSupported
+}
+
+// ReadStats is not supported on this platform.
+func ReadStats(dir string) (*Stats, error) {
+	return nil, errNotSupported
+}
@@ -0,0 +1,158 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	defaultCPUPeriod = 100000
+	maxValue         = "max"
+)
+
+// Resources translates cgroup v1 resources to the cgroup v2 controller files
+// they map to, keyed by file name. Resources that are not set, or have no
+// equivalent in the unified hierarchy, are left out.
+//
+// The memory resources must be complete, as they are applied to a running
+// container: memory.swap.max is derived from both the memory limit and the
+// memory+swap limit, and the memory limits that are not set are reset to
+// their default, so that a limit removed by an update is lifted.
+func Resources(r *specs.LinuxResources) map[string]string {
+	unified := make(map[string]string)
+	if r == nil {
+		return unified
+	}
+	if m := r.Memory; m != nil {
+		var memory int64
+		if m.Limit != nil {
+			memory = *m.Limit
+		}
+		unified["memory.max"] = maxValue
+		if memory > 0 {
+			unified["memory.max"] = strconv.FormatInt(memory, 10)
+		}
+		unified["memory.low"] = "0"
+		if m.Reservation != nil && *m.Reservation > 0 {
+			unified["memory.low"] = strconv.FormatInt(*m.Reservation, 10)
+		}
+		// memory.swap.max only limits swap, whereas the v1 limit covers
+		// memory and swap. Swap is unlimited unless both are limited.
+		unified["memory.swap.max"] = maxValue
+		if m.Swap != nil && *m.Swap > 0 && memory > 0 {
+			swap := *m.Swap - memory
+			if swap < 0 {
+				swap = 0
+			}
+			unified["memory.swap.max"] = strconv.FormatInt(swap, 10)
+		}
+		if m.Swapfile != nil && *m.Swapfile != "" {
+			unified["memory.swapfile"] = *m.Swapfile
+		}
+	}
+	if c := r.CPU; c != nil {
+		if c.Shares != nil && *c.Shares > 0 {
+			unified["cpu.weight"] = strconv.FormatUint(cpuWeight(*c.Shares), 10)
+		}
+		if c.Quota != nil && (*c.Quota > 0 || *c.Quota == -1) {
+			period := uint64(defaultCPUPeriod)
+			if c.Period != nil && *c.Period != 0 {
+				period = *c.Period
+			}
+			unified["cpu.max"] = fmt.Sprintf("%s %d", limit(*c.Quota), period)
+		}
+		if c.Cpus != "" {
+			unified["cpuset.cpus"] = c.Cpus
+		}
+		if c.Mems != "" {
+			unified["cpuset.mems"] = c.Mems
+		}
+	}
+	if p := r.Pids; p != nil {
+		if v := limit(p.Limit); v != "" {
+			unified["pids.max"] = v
+		}
+	}
+	if b := r.BlockIO; b != nil {
+		var weights []string
+		if b.Weight != nil && *b.Weight > 0 {
+			weights = append(weights, fmt.Sprintf("default %d", ioWeight(*b.Weight)))
+		}
+		for _, d := range b.WeightDevice {
+			if d.Weight != nil && *d.Weight > 0 {
+				weights = append(weights, fmt.Sprintf("%d:%d %d", d.Major, d.Minor, ioWeight(*d.Weight)))
+			}
+		}
+		if len(weights) > 0 {
+			unified["io.weight"] = strings.Join(weights, "\n")
+		}
+		if max := ioMax(b); max != "" {
+			unified["io.max"] = max
+		}
+	}
+	return unified
+}
+
+// limit formats a v1 limit, where -1 means unlimited and 0 means unset.
+func limit(v int64) string {
+	switch {
+	case v == -1:
+		return maxValue
+	case v > 0:
+		return strconv.FormatInt(v, 10)
+	}
+	return ""
+}
+
+// cpuWeight converts CPU shares, in [2, 262144], to a cpu.weight, in
+// [1, 10000].
+func cpuWeight(shares uint64) uint64 {
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// ioWeight converts a Block IO weight, in [10, 1000], to an io.weight, in
+// [1, 10000].
+func ioWeight(weight uint16) uint64 {
+	w := uint64(weight)
+	if w < 10 {
+		w = 10
+	}
+	if w > 1000 {
+		w = 1000
+	}
+	return 1 + (w-10)*9999/990
+}
+
+// ioMax merges the throttling limits of the devices into io.max lines, one
+// per device.
+func ioMax(b *specs.LinuxBlockIO) string {
+	type device struct{ major, minor int64 }
+	limits := make(map[device][]string)
+	add := func(key string, throttles []specs.LinuxThrottleDevice) {
+		for _, t := range throttles {
+			d := device{t.Major, t.Minor}
+			limits[d] = append(limits[d], fmt.Sprintf("%s=%d", key, t.Rate))
+		}
+	}
+	add("rbps", b.ThrottleReadBpsDevice)
+	add("wbps", b.ThrottleWriteBpsDevice)
+	add("riops", b.ThrottleReadIOPSDevice)
+	add("wiops", b.ThrottleWriteIOPSDevice)
+
+	var lines []string
+	for d, l := range limits {
+		lines = append(lines, fmt.Sprintf("%d:%d %s", d.major, d.minor, strings.Join(l, " ")))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,141 @@
+package cgroup2 // import "github.com/docker/docker/pkg/cgroup2"
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestResources(t *testing.T) {
+	var (
+		memory      int64  = 512 * 1024 * 1024
+		memsw       int64  = 1024 * 1024 * 1024
+		reservation int64  = 256 * 1024 * 1024
+		swapfile           = "/swap/nvme0"
+		shares      uint64 = 1024
+		quota       int64  = 50000
+		period      uint64 = 100000
+		weight      uint16 = 500
+		devWeight   uint16 = 10
+	)
+	r := &specs.LinuxResources{
+		Memory: &specs.LinuxMemory{
+			Limit:       &memory,
+			Swap:        &memsw,
+			Reservation: &reservation,
+			Swapfile:    &swapfile,
+		},
+		CPU: &specs.LinuxCPU{
+			Shares: &shares,
+			Quota:  &quota,
+			Period: &period,
+			Cpus:   "0-1",
+		},
+		Pids: &specs.LinuxPids{Limit: 100},
+		BlockIO: &specs.LinuxBlockIO{
+			Weight: &weight,
+			WeightDevice: []specs.LinuxWeightDevice{
+				weightDevice(8, 0, devWeight),
+			},
+			ThrottleReadBpsDevice: []specs.LinuxThrottleDevice{
+				throttleDevice(8, 0, 1048576),
+			},
+			ThrottleWriteIOPSDevice: []specs.LinuxThrottleDevice{
+				throttleDevice(8, 0, 100),
+				throttleDevice(8, 16, 200),
+			},
+		},
+	}
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"memory.max":      "536870912",
+		"memory.swap.max": "536870912",
+		"memory.low":      "268435456",
+		"memory.swapfile": "/swap/nvme0",
+		"cpu.weight":      "39",
+		"cpu.max":         "50000 100000",
+		"cpuset.cpus":     "0-1",
+		"pids.max":        "100",
+		"io.weight":       "default 4950\n8:0 1",
+		"io.max":          "8:0 rbps=1048576 wiops=100\n8:16 wiops=200",
+	}, Resources(r)))
+}
+
+func TestResourcesUnlimited(t *testing.T) {
+	var (
+		memory int64 = -1
+		memsw  int64 = -1
+		quota  int64 = -1
+	)
+	r := &specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &memory, Swap: &memsw},
+		CPU:    &specs.LinuxCPU{Quota: &quota},
+		Pids:   &specs.LinuxPids{Limit: -1},
+	}
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"memory.max":      "max",
+		"memory.swap.max": "max",
+		"memory.low":      "0",
+		"cpu.max":         "max 100000",
+		"pids.max":        "max",
+	}, Resources(r)))
+}
+
+func TestResourcesUnset(t *testing.T) {
+	var zero int64
+	r := &specs.LinuxResources{
+		CPU: &specs.LinuxCPU{Quota: &zero},
+	}
+	assert.Check(t, is.Len(Resources(r), 0))
+	assert.Check(t, is.Len(Resources(nil), 0))
+}
+
+func TestResourcesMemoryReset(t *testing.T) {
+	var (
+		zero   int64
+		memory int64 = 512 * 1024 * 1024
+	)
+	// memory limits that are not set are reset to their default
+	expected := map[string]string{
+		"memory.max":      "max",
+		"memory.swap.max": "max",
+		"memory.low":      "0",
+	}
+	assert.Check(t, is.DeepEqual(expected, Resources(&specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &zero, Swap: &zero, Reservation: &zero},
+	})))
+	assert.Check(t, is.DeepEqual(expected, Resources(&specs.LinuxResources{
+		Memory: &specs.LinuxMemory{},
+	})))
+
+	// swap is unlimited unless both memory and memory+swap are limited
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"memory.max":      "536870912",
+		"memory.swap.max": "max",
+		"memory.low":      "0",
+	}, Resources(&specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &memory},
+	})))
+
+	// memory+swap equal to memory disables swap
+	assert.Check(t, is.DeepEqual(map[string]string{
+		"memory.max":      "536870912",
+		"memory.swap.max": "0",
+		"memory.low":      "0",
+	}, Resources(&specs.LinuxResources{
+		Memory: &specs.LinuxMemory{Limit: &memory, Swap: &memory},
+	})))
+}
+
+func weightDevice(major, minor int64, weight uint16) specs.LinuxWeightDevice {
+	d := specs.LinuxWeightDevice{Weight: &weight}
+	d.Major, d.Minor = major, minor
+	return d
+}
+
+func throttleDevice(major, minor int64, rate uint64) specs.LinuxThrottleDevice {
+	d := specs.LinuxThrottleDevice{Rate: rate}
+	d.Major, d.Minor = major, minor
+	return d
+}
@@ -0,0 +1,39 @@
+package swap // import "github.com/docker/docker/pkg/swap"
+
+// Area describes an active swap area as reported by the kernel.
+type Area struct {
+	// Path is the swap file or block device backing the area.
+	Path string
+	// Type is either "file" or "partition".
+	Type string
+	// Size is the total size of the area in bytes.
+	Size int64
+	// Used is the number of bytes currently swapped out to the area.
+	Used int64
+	// Priority is the swap priority of the area.
+	Priority int
+}
+
+// Get returns the active swap area backed by path, or nil if no such area
+// is active.
+func Get(path string) (*Area, error) {
+	areas, err := GetAreas()
+	if err != nil {
+		return nil, err
+	}
+	for i := range areas {
+		if areas[i].Path == path {
+			return &areas[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// IsActive returns true if the swap file or device at path is active.
+func IsActive(path string) (bool, error) {
+	a, err := Get(path)
+	if err != nil {
+		return false, err
+	}
+	return a != nil, nil
+}
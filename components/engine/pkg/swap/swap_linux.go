@@ -0,0 +1,158 @@
+package swap // import "github.com/docker/docker/pkg/swap"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	procSwaps = "/proc/swaps"
+
+	// flags accepted by swapon(2), see <sys/swap.h>
+	swapFlagPrefer   = 0x8000
+	swapFlagPrioMask = 0x7fff
+)
+
+// GetAreas returns the swap areas currently active on the host, as listed
+// in /proc/swaps.
+func GetAreas() ([]Area, error) {
+	f, err := os.Open(procSwaps)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseSwaps(f)
+}
+
+func parseSwaps(r io.Reader) ([]Area, error) {
+	/*
+	   Filename                                Type            Size    Used    Priority
+	   /dev/sda2                               partition       8388604 0       -2
+	   /swap/nvme0                             file            2097148 1024    10
+
+	   Size and Used are expressed in KiB. Whitespace in the filename is
+	   escaped as octal (e.g. "\040").
+	*/
+	s := bufio.NewScanner(r)
+	out := []Area{}
+	header := true
+	for s.Scan() {
+		if header {
+			header = false
+			continue
+		}
+		text := s.Text()
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("Parsing '%s' failed: unexpected number of fields (%d)", text, len(fields))
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing '%s' failed: invalid size: %v", text, err)
+		}
+		used, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing '%s' failed: invalid used size: %v", text, err)
+		}
+		prio, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("Parsing '%s' failed: invalid priority: %v", text, err)
+		}
+		out = append(out, Area{
+			Path:     unescape(fields[0]),
+			Type:     fields[1],
+			Size:     size * 1024,
+			Used:     used * 1024,
+			Priority: prio,
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// unescape decodes the octal escapes the kernel uses for whitespace and
+// backslashes in /proc/swaps file names.
+func unescape(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if v, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// Create allocates a swap file of the given size (in bytes) at path and
+// formats it with mkswap. The file must not exist yet.
+func Create(path string, size int64) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	// swapon(2) refuses files with holes, so the blocks must actually be
+	// allocated rather than merely reserved with a truncate.
+	if err := unix.Fallocate(int(f.Fd()), 0, 0, size); err != nil {
+		f.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to allocate swap file %s: %v", path, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return err
+	}
+	if out, err := exec.Command("mkswap", path).CombinedOutput(); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to format swap file %s: %v (%s)", path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// On activates the swap file or device at path. A negative priority leaves
+// the choice of priority to the kernel.
+func On(path string, priority int) error {
+	p, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	var flags uintptr
+	if priority >= 0 {
+		flags = swapFlagPrefer | uintptr(priority&swapFlagPrioMask)
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_SWAPON, uintptr(unsafe.Pointer(p)), flags, 0); errno != 0 {
+		return &os.PathError{Op: "swapon", Path: path, Err: errno}
+	}
+	return nil
+}
+
+// Off deactivates the swap file or device at path.
+func Off(path string) error {
+	p, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return err
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_SWAPOFF, uintptr(unsafe.Pointer(p)), 0, 0); errno != 0 {
+		return &os.PathError{Op: "swapoff", Path: path, Err: errno}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package swap // import "github.com/docker/docker/pkg/swap"
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+const procSwapsFixture = `Filename				Type		Size	Used	Priority
+/dev/sda2                               partition	8388604	0	-2
+/swap/nvme0                             file		2097148	1024	10
+/var/lib/docker/swap/my\040swap         file		1024	0	-3
+`
+
+func TestParseSwaps(t *testing.T) {
+	areas, err := parseSwaps(strings.NewReader(procSwapsFixture))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual([]Area{
+		{Path: "/dev/sda2", Type: "partition", Size: 8388604 * 1024, Used: 0, Priority: -2},
+		{Path: "/swap/nvme0", Type: "file", Size: 2097148 * 1024, Used: 1024 * 1024, Priority: 10},
+		{Path: "/var/lib/docker/swap/my swap", Type: "file", Size: 1024 * 1024, Used: 0, Priority: -3},
+	}, areas))
+}
+
+func TestParseSwapsEmpty(t *testing.T) {
+	areas, err := parseSwaps(strings.NewReader("Filename\t\t\t\tType\t\tSize\tUsed\tPriority\n"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(areas, 0))
+}
+
+func TestParseSwapsInvalid(t *testing.T) {
+	_, err := parseSwaps(strings.NewReader("Filename Type Size Used Priority\n/dev/sda2 partition foo 0 -2\n"))
+	assert.Check(t, is.ErrorContains(err, "invalid size"))
+}
@@ -0,0 +1,30 @@
+// +build !linux
+
+package swap // import "github.com/docker/docker/pkg/swap"
+
+import (
+	"fmt"
+	"runtime"
+)
+
+var errNotSupported = fmt.Errorf("swap areas are not supported on %s/%s", runtime.GOOS, runtime.GOARCH)
+
+// GetAreas is not supported on this platform.
+func GetAreas() ([]Area, error) {
+	return nil, errNotSupported
+}
+
+// Create is not supported on this platform.
+func Create(path string, size int64) error {
+	return errNotSupported
+}
+
+// On is not supported on this platform.
+func On(path string, priority int) error {
+	return errNotSupported
+}
+
+// Off is not supported on this platform.
+func Off(path string) error {
+	return errNotSupported
+}
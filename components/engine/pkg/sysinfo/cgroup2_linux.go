@@ -0,0 +1,129 @@
+package sysinfo // import "github.com/docker/docker/pkg/sysinfo"
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/pkg/cgroup2"
+	"github.com/sirupsen/logrus"
+)
+
+// newV2 fills in the cgroup information of a host running the cgroup v2
+// unified hierarchy.
+func newV2(sysInfo *SysInfo, quiet bool) {
+	sysInfo.CgroupUnified = true
+	// devices are controlled with eBPF programs, which need no controller
+	sysInfo.CgroupDevicesEnabled = true
+
+	controllers, err := cgroup2.Controllers()
+	if err != nil {
+		logrus.Warnf("Failed to read the cgroup v2 controllers: %v", err)
+		return
+	}
+	// The root cgroup has no controller files: look them up in the cgroup
+	// of the daemon instead.
+	ownPath, err := cgroup2.ProcessPath(os.Getpid())
+	if err != nil {
+		ownPath = cgroup2.Mountpoint
+	}
+	checkCgroup2(sysInfo, controllers, ownPath, quiet)
+}
+
+// checkCgroup2 reads the cgroup information from the controllers available in
+// the unified hierarchy, and from the controller files of the cgroup in dir.
+func checkCgroup2(sysInfo *SysInfo, controllers []string, dir string, quiet bool) {
+	available := make(map[string]bool)
+	for _, c := range controllers {
+		available[c] = true
+	}
+
+	if available["memory"] {
+		sysInfo.cgroupMemInfo = checkCgroup2Mem(dir, quiet)
+	} else if !quiet {
+		logrus.Warn("Your kernel does not support cgroup memory limit")
+	}
+
+	if available["cpu"] {
+		// real-time scheduling is not supported by the cpu controller of
+		// the unified hierarchy
+		sysInfo.cgroupCPUInfo = cgroupCPUInfo{
+			CPUShares:    true,
+			CPUCfsPeriod: true,
+			CPUCfsQuota:  true,
+		}
+	} else if !quiet {
+		logrus.Warn("Unable to find cpu controller in the cgroup v2 hierarchy")
+	}
+
+	if available["io"] {
+		sysInfo.cgroupBlkioInfo = cgroupBlkioInfo{
+			BlkioWeight:          true,
+			BlkioWeightDevice:    true,
+			BlkioReadBpsDevice:   true,
+			BlkioWriteBpsDevice:  true,
+			BlkioReadIOpsDevice:  true,
+			BlkioWriteIOpsDevice: true,
+		}
+	} else if !quiet {
+		logrus.Warn("Unable to find io controller in the cgroup v2 hierarchy")
+	}
+
+	if available["cpuset"] {
+		sysInfo.cgroupCpusetInfo = checkCgroup2Cpuset(cgroup2.Mountpoint)
+	} else if !quiet {
+		logrus.Warn("Unable to find cpuset controller in the cgroup v2 hierarchy")
+	}
+
+	if available["pids"] {
+		sysInfo.cgroupPids = cgroupPids{PidsLimit: true}
+	} else if !quiet {
+		logrus.Warn("Unable to find pids controller in the cgroup v2 hierarchy")
+	}
+}
+
+// checkCgroup2Mem reads the memory information from the memory controller
+// files of the cgroup in dir.
+func checkCgroup2Mem(dir string, quiet bool) cgroupMemInfo {
+	// If the memory controller is not enabled in dir, assume the kernel
+	// accounts for swap, as it does by default.
+	swapLimit := !cgroupEnabled(dir, "memory.current") || cgroupEnabled(dir, "memory.swap.max")
+	if !quiet && !swapLimit {
+		logrus.Warn("Your kernel does not support swap memory limit")
+	}
+	memorySwapfile := cgroupEnabled(dir, "memory.swapfile")
+	if !quiet && !memorySwapfile {
+		logrus.Warn("Your kernel does not support memory swapfile")
+	}
+	if !quiet {
+		logrus.Warn("cgroup v2 does not support memory swappiness, oom control and kernel memory limit")
+	}
+
+	return cgroupMemInfo{
+		MemoryLimit:       true,
+		SwapLimit:         swapLimit,
+		MemoryReservation: true,
+		MemorySwapfile:    memorySwapfile,
+	}
+}
+
+// checkCgroup2Cpuset reads the cpus and memory nodes available to the
+// cgroup in dir.
+func checkCgroup2Cpuset(dir string) cgroupCpusetInfo {
+	cpus, err := ioutil.ReadFile(path.Join(dir, "cpuset.cpus.effective"))
+	if err != nil {
+		return cgroupCpusetInfo{}
+	}
+
+	mems, err := ioutil.ReadFile(path.Join(dir, "cpuset.mems.effective"))
+	if err != nil {
+		return cgroupCpusetInfo{}
+	}
+
+	return cgroupCpusetInfo{
+		Cpuset: true,
+		Cpus:   strings.TrimSpace(string(cpus)),
+		Mems:   strings.TrimSpace(string(mems)),
+	}
+}
@@ -0,0 +1,51 @@
+package sysinfo // import "github.com/docker/docker/pkg/sysinfo"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestCheckCgroup2(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, file := range []string{"memory.current", "memory.max", "memory.swap.max"} {
+		assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte("max\n"), 0644))
+	}
+
+	sysInfo := &SysInfo{}
+	checkCgroup2(sysInfo, []string{"cpu", "memory", "pids"}, dir, true)
+	assert.Check(t, sysInfo.MemoryLimit)
+	assert.Check(t, sysInfo.SwapLimit)
+	assert.Check(t, sysInfo.MemoryReservation)
+	assert.Check(t, !sysInfo.MemorySwappiness)
+	assert.Check(t, !sysInfo.MemorySwapfile)
+	assert.Check(t, !sysInfo.OomKillDisable)
+	assert.Check(t, !sysInfo.KernelMemory)
+	assert.Check(t, sysInfo.CPUCfsQuota)
+	assert.Check(t, !sysInfo.CPURealtimePeriod)
+	assert.Check(t, sysInfo.PidsLimit)
+	assert.Check(t, !sysInfo.BlkioWeight)
+	assert.Check(t, !sysInfo.Cpuset)
+}
+
+func TestCheckCgroup2NoSwapAccounting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup2-test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "memory.current"), []byte("0\n"), 0644))
+
+	sysInfo := &SysInfo{}
+	checkCgroup2(sysInfo, []string{"memory", "io"}, dir, true)
+	assert.Check(t, sysInfo.MemoryLimit)
+	assert.Check(t, is.Equal(false, sysInfo.SwapLimit))
+	assert.Check(t, sysInfo.BlkioWeight)
+	assert.Check(t, sysInfo.BlkioWriteIOpsDevice)
+}
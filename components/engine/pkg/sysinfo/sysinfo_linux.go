@@ -7,6 +7,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/docker/docker/pkg/cgroup2"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
@@ -31,19 +32,23 @@ func findCgroupMountpoints() (map[string]string, error) {
 // whenever an error occurs or misconfigurations are present.
 func New(quiet bool) *SysInfo {
 	sysInfo := &SysInfo{}
-	cgMounts, err := findCgroupMountpoints()
-	if err != nil {
-		logrus.Warnf("Failed to parse cgroup information: %v", err)
+	if cgroup2.IsEnabled() {
+		newV2(sysInfo, quiet)
 	} else {
-		sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
-		sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
-		sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
-		sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
-		sysInfo.cgroupPids = checkCgroupPids(quiet)
-	}
+		cgMounts, err := findCgroupMountpoints()
+		if err != nil {
+			logrus.Warnf("Failed to parse cgroup information: %v", err)
+		} else {
+			sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
+			sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
+			sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
+			sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
+			sysInfo.cgroupPids = checkCgroupPids(quiet)
+		}
 
-	_, ok := cgMounts["devices"]
-	sysInfo.CgroupDevicesEnabled = ok
+		_, ok := cgMounts["devices"]
+		sysInfo.CgroupDevicesEnabled = ok
+	}
 
 	sysInfo.IPv4ForwardingDisabled = !readProcBool("/proc/sys/net/ipv4/ip_forward")
 	sysInfo.BridgeNFCallIPTablesDisabled = !readProcBool("/proc/sys/net/bridge/bridge-nf-call-iptables")
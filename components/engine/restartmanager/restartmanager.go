@@ -10,9 +10,10 @@ import (
 )
 
 const (
-	backoffMultiplier = 2
-	defaultTimeout    = 100 * time.Millisecond
-	maxRestartTimeout = 1 * time.Minute
+	backoffMultiplier  = 2
+	defaultTimeout     = 100 * time.Millisecond
+	maxRestartTimeout  = 1 * time.Minute
+	defaultResetWindow = 10 * time.Second
 )
 
 // ErrRestartCanceled is returned when the restart manager has been
@@ -23,6 +24,9 @@ var ErrRestartCanceled = errors.New("restart canceled")
 type RestartManager interface {
 	Cancel() error
 	ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error)
+	// SetUnhealthy records that the container is being stopped because it
+	// became unhealthy, so that its next exit is handled as a failure.
+	SetUnhealthy()
 }
 
 type restartManager struct {
@@ -32,6 +36,7 @@ type restartManager struct {
 	restartCount int
 	timeout      time.Duration
 	active       bool
+	unhealthy    bool
 	cancel       chan struct{}
 	canceled     bool
 }
@@ -47,6 +52,31 @@ func (rm *restartManager) SetPolicy(policy container.RestartPolicy) {
 	rm.Unlock()
 }
 
+func (rm *restartManager) SetUnhealthy() {
+	rm.Lock()
+	rm.unhealthy = true
+	rm.Unlock()
+}
+
+// backoff returns the delay before the first restart, the maximum delay,
+// and how long the container must run for the delay to be reset.
+func (rm *restartManager) backoff() (initial, max, reset time.Duration) {
+	initial, max, reset = defaultTimeout, maxRestartTimeout, defaultResetWindow
+	if rm.policy.InitialDelay > 0 {
+		initial = rm.policy.InitialDelay
+	}
+	if rm.policy.MaxDelay > 0 {
+		max = rm.policy.MaxDelay
+	}
+	if max < initial {
+		max = initial
+	}
+	if rm.policy.ResetWindow > 0 {
+		reset = rm.policy.ResetWindow
+	}
+	return initial, max, reset
+}
+
 func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, chan error, error) {
 	if rm.policy.IsNone() {
 		return false, nil, nil
@@ -66,19 +96,25 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, fmt.Errorf("invalid call on an active restart manager")
 	}
-	// if the container ran for more than 10s, regardless of status and policy reset the
-	// the timeout back to the default.
-	if executionDuration.Seconds() >= 10 {
+	// a container stopped because it became unhealthy has failed, whatever
+	// its exit code
+	unhealthy := rm.unhealthy
+	rm.unhealthy = false
+
+	initialDelay, maxDelay, resetWindow := rm.backoff()
+	// if the container ran for longer than the reset window, regardless of status and
+	// policy reset the timeout back to the initial delay.
+	if executionDuration >= resetWindow {
 		rm.timeout = 0
 	}
 	switch {
 	case rm.timeout == 0:
-		rm.timeout = defaultTimeout
-	case rm.timeout < maxRestartTimeout:
+		rm.timeout = initialDelay
+	case rm.timeout < maxDelay:
 		rm.timeout *= backoffMultiplier
 	}
-	if rm.timeout > maxRestartTimeout {
-		rm.timeout = maxRestartTimeout
+	if rm.timeout > maxDelay {
+		rm.timeout = maxDelay
 	}
 
 	var restart bool
@@ -90,7 +126,7 @@ func (rm *restartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	case rm.policy.IsOnFailure():
 		// the default value of 0 for MaximumRetryCount means that we will not enforce a maximum count
 		if max := rm.policy.MaximumRetryCount; max == 0 || rm.restartCount < max {
-			restart = exitCode != 0
+			restart = exitCode != 0 || unhealthy
 		}
 	}
 
@@ -34,3 +34,46 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerBackoff(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: time.Second,
+		MaxDelay:     3 * time.Second,
+		ResetWindow:  time.Minute,
+	}
+	rm := New(policy, 0).(*restartManager)
+	for _, expected := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		_, _, err := rm.ShouldRestart(0, false, 30*time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rm.timeout != expected {
+			t.Fatalf("restart manager should have a timeout of %s but has %s", expected, rm.timeout)
+		}
+		// do not wait for the restart
+		rm.Lock()
+		rm.active = false
+		rm.Unlock()
+	}
+
+	_, _, err := rm.ShouldRestart(0, false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rm.timeout != time.Second {
+		t.Fatalf("restart manager should have a timeout of 1s but has %s", rm.timeout)
+	}
+}
+
+func TestRestartManagerUnhealthy(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "on-failure"}, 0).(*restartManager)
+	rm.SetUnhealthy()
+	should, _, err := rm.ShouldRestart(0, false, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !should {
+		t.Fatal("container stopped because it became unhealthy should be restarted")
+	}
+}